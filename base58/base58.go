@@ -1,5 +1,7 @@
 package base58
 
+import "errors"
+
 // Alphabet is a a b58 alphabet.
 type Alphabet struct {
 	decode [128]int8
@@ -15,20 +17,26 @@ var encode = [58]byte{
 	'h', 'i', 'j', 'k', 'm', 'n', 'o', 'p', 'q', 'r',
 	's', 't', 'u', 'v', 'w', 'x', 'y', 'z',
 }
-var decode = [128]uint64{
-	'1': 0, '2': 1, '3': 2, '4': 3, '5': 4,
-	'6': 5, '7': 6, '8': 7, '9': 8, 'A': 9,
-	'B': 10, 'C': 11, 'D': 12, 'E': 13, 'F': 14,
-	'G': 15, 'H': 16, 'J': 17, 'K': 18, 'L': 19,
-	'M': 20, 'N': 21, 'P': 22, 'Q': 23, 'R': 24,
-	'S': 25, 'T': 26, 'U': 27, 'V': 28, 'W': 29,
-	'X': 30, 'Y': 31, 'Z': 32, 'a': 33, 'b': 34,
-	'c': 35, 'd': 36, 'e': 37, 'f': 38, 'g': 39,
-	'h': 40, 'i': 41, 'j': 42, 'k': 43, 'm': 44,
-	'n': 45, 'o': 46, 'p': 47, 'q': 48, 'r': 49,
-	's': 50, 't': 51, 'u': 52, 'v': 53, 'w': 54,
-	'x': 55, 'y': 56, 'z': 57,
-}
+
+// invalidChar marks bytes outside the alphabet in decode. It has a bit set
+// that no valid digit has, so digits can be or-ed together and checked once.
+const invalidChar = 64
+
+var decode = func() [256]uint64 {
+	var d [256]uint64
+	for i := range d {
+		d[i] = invalidChar
+	}
+	for i, c := range encode {
+		d[c] = uint64(i)
+	}
+	return d
+}()
+
+var (
+	errInvalidChar = errors.New("base58: invalid character")
+	errOverflow    = errors.New("base58: value overflows 128 bits")
+)
 var padLeft = [22]string{
 	"",
 	"1",
@@ -56,6 +64,25 @@ var padLeft = [22]string{
 
 var uuidSize = 16
 
+// maxEncoded is the encoding of the largest 16-byte value. Because the
+// alphabet is in ASCII order, any 22 character string from the alphabet that
+// sorts after it would overflow 128 bits.
+const maxEncoded = "YcVfxkQb6JRzqk5kF2tNLv"
+
+// ValidString reports whether str is the 22 character base58 encoding of a
+// 16-byte value.
+func ValidString(str string) bool {
+	if len(str) != len(maxEncoded) {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if decode[str[i]] == invalidChar {
+			return false
+		}
+	}
+	return str <= maxEncoded
+}
+
 func Decode(str string) ([]byte, error) {
 	dst := make([]byte, uuidSize)
 	if err := UnmarshalString(dst, str); err != nil {
@@ -73,6 +100,10 @@ func UnmarshalBytes(dst, src []byte) error {
 	// Use stack allocation for better performance
 	var outi [4]uint32
 
+	// bad collects every digit and ovf every carry out of the top word, so
+	// invalid characters and overflow are checked once after decoding.
+	var bad, ovf uint64
+
 	// Optimized for the common case of 22-byte base58 UUID
 	if len(src) == 22 {
 		// Unrolled loop for base58 decoding
@@ -83,6 +114,7 @@ func UnmarshalBytes(dst, src []byte) error {
 		for i := 0; i < 22; i += 2 {
 			// First character
 			c = decode[src[i]]
+			bad |= c
 			t3 := uint64(outi[3])*58 + c
 			c = t3 >> 32
 			outi[3] = uint32(t3)
@@ -96,11 +128,13 @@ func UnmarshalBytes(dst, src []byte) error {
 			outi[1] = uint32(t1)
 
 			t0 := uint64(outi[0])*58 + c
+			ovf |= t0 >> 32
 			outi[0] = uint32(t0)
 
 			// Second character (if exists)
 			if i+1 < 22 {
 				c = decode[src[i+1]]
+				bad |= c
 				t3 = uint64(outi[3])*58 + c
 				c = t3 >> 32
 				outi[3] = uint32(t3)
@@ -114,6 +148,7 @@ func UnmarshalBytes(dst, src []byte) error {
 				outi[1] = uint32(t1)
 
 				t0 = uint64(outi[0])*58 + c
+				ovf |= t0 >> 32
 				outi[0] = uint32(t0)
 			}
 		}
@@ -121,15 +156,24 @@ func UnmarshalBytes(dst, src []byte) error {
 		// Fallback for non-standard lengths
 		for i := 0; i < len(src); i++ {
 			c := decode[src[i]]
+			bad |= c
 
 			for j := 3; j >= 0; j-- {
 				t := uint64(outi[j])*58 + c
 				c = t >> 32
 				outi[j] = uint32(t)
 			}
+			ovf |= c
 		}
 	}
 
+	if bad&invalidChar != 0 {
+		return errInvalidChar
+	}
+	if ovf != 0 {
+		return errOverflow
+	}
+
 	// Unrolled output conversion
 	dst[0] = byte(outi[0] >> 24)
 	dst[1] = byte(outi[0] >> 16)
@@ -155,14 +199,25 @@ func UnmarshalBytes(dst, src []byte) error {
 }
 
 func Encode(bin []byte) string {
+	var out [22]byte
+	MarshalBytes(out[:], bin)
+	return string(out[:])
+}
+
+// MarshalBytes writes the 22 character base58 encoding of the 16-byte src
+// into dst, padded on the left with '1'.
+func MarshalBytes(dst, src []byte) {
 	// A UUID will result in a base58 string of at most 22 characters.
 	// This calculation is specific to 128-bit numbers (UUIDs).
 	const maxEncodedSize = 22
-	out := [22]byte{}
+	out := dst[:maxEncodedSize]
+	for i := range out {
+		out[i] = 0
+	}
 	var outIndex int = maxEncodedSize - 1 // Start filling from the end
 
 	for i := 0; i < uuidSize; i++ {
-		carry := uint32(bin[i])
+		carry := uint32(src[i])
 
 		for j := maxEncodedSize - 1; j >= outIndex; j-- {
 			carry += uint32(out[j]) * 256
@@ -177,22 +232,11 @@ func Encode(bin []byte) string {
 		}
 	}
 
-	for i := outIndex; i < maxEncodedSize; i++ {
-		out[i] = encode[out[i]]
-	}
-
-	if outIndex == 0 {
-		return string(out[:])
-	}
-
-	totalLen := 22 // Always 22 for padded result
-	result := make([]byte, totalLen)
-
 	// Fill padding with '1' characters
 	for i := 0; i < outIndex; i++ {
-		result[i] = '1'
+		out[i] = '1'
+	}
+	for i := outIndex; i < maxEncodedSize; i++ {
+		out[i] = encode[out[i]]
 	}
-
-	copy(result[outIndex:], out[outIndex:])
-	return string(result)
 }
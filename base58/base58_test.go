@@ -87,3 +87,56 @@ func BenchmarkUnmarshalBytesNewMultiple(b *testing.B) {
 		}
 	}
 }
+
+func TestValidString(t *testing.T) {
+	valid := []string{
+		"1111111111111111111111",
+		"1C9z3nFjeJ44HMBeuqGNxt",
+		"YcVfxkQb6JRzqk5kF2tNLv",
+	}
+	for _, s := range valid {
+		if !ValidString(s) {
+			t.Errorf("ValidString(%q) = false, want true", s)
+		}
+	}
+	invalid := []string{
+		"",
+		"1C9z3nFjeJ44HMBeuqGNx",
+		"1C9z3nFjeJ44HMBeuqGNxtt",
+		"0C9z3nFjeJ44HMBeuqGNxt",
+		"IC9z3nFjeJ44HMBeuqGNxt",
+		"lC9z3nFjeJ44HMBeuqGNxt",
+		"1C9z3nFjeJ44HMBeuqGNx\xff",
+		"YcVfxkQb6JRzqk5kF2tNLw",
+		"zzzzzzzzzzzzzzzzzzzzzz",
+	}
+	for _, s := range invalid {
+		if ValidString(s) {
+			t.Errorf("ValidString(%q) = true, want false", s)
+		}
+	}
+}
+
+func TestUnmarshalBytesInvalid(t *testing.T) {
+	tests := []struct {
+		src  string
+		want error
+	}{
+		{src: "0C9z3nFjeJ44HMBeuqGNxt", want: errInvalidChar},
+		{src: "IC9z3nFjeJ44HMBeuqGNxt", want: errInvalidChar},
+		{src: "1C9z3nFjeJ44HMBeuqGNx\xff", want: errInvalidChar},
+		{src: "\x80C9z3nFjeJ44HMBeuqGNxt", want: errInvalidChar},
+		{src: "1C9z3nFjeJ44HMBeu-GNxt", want: errInvalidChar},
+		{src: "YcVfxkQb6JRzqk5kF2tNLw", want: errOverflow},
+		{src: "zzzzzzzzzzzzzzzzzzzzzz", want: errOverflow},
+		{src: "1C9z3nFjeJ44HMBeuqGNx\x00", want: errInvalidChar},
+		{src: "\xff", want: errInvalidChar},
+		{src: "zzzzzzzzzzzzzzzzzzzzzzz", want: errOverflow},
+	}
+	dst := make([]byte, 16)
+	for _, tt := range tests {
+		if err := UnmarshalBytes(dst, []byte(tt.src)); err != tt.want {
+			t.Errorf("UnmarshalBytes(%q) error = %v, want %v", tt.src, err, tt.want)
+		}
+	}
+}
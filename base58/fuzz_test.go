@@ -0,0 +1,42 @@
+package base58
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzUnmarshalBytes(f *testing.F) {
+	for _, s := range []string{
+		"",
+		"1",
+		"1111111111111111111111",
+		"1C9z3nFjeJ44HMBeuqGNxt",
+		"EJ34kCVxxF9jHMKD4EgrAK",
+		"YcVfxkQb6JRzqk5kF2tNLv",
+		"YcVfxkQb6JRzqk5kF2tNLw",
+		"zzzzzzzzzzzzzzzzzzzzzz",
+		"0C9z3nFjeJ44HMBeuqGNxt",
+		"1C9z3nFjeJ44HMBeuqGNx\x80",
+		"1C9z3nFjeJ44HMBeuqGNx\xff",
+	} {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, src []byte) {
+		dst := make([]byte, uuidSize)
+		err := UnmarshalBytes(dst, src)
+		if len(src) == 22 && (err == nil) != ValidString(string(src)) {
+			t.Fatalf("UnmarshalBytes(%q) error = %v, but ValidString = %t", src, err, ValidString(string(src)))
+		}
+		if err != nil {
+			return
+		}
+		enc := Encode(dst)
+		if len(src) == 22 && enc != string(src) {
+			t.Fatalf("Encode(UnmarshalBytes(%q)) = %q", src, enc)
+		}
+		again := make([]byte, uuidSize)
+		if err := UnmarshalString(again, enc); err != nil || !bytes.Equal(again, dst) {
+			t.Fatalf("UnmarshalString(%q) = %x, %v, want %x", enc, again, err, dst)
+		}
+	})
+}
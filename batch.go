@@ -0,0 +1,44 @@
+package uuid
+
+import "fmt"
+
+// ParseMany parses src[i] into dst[i] for every i, in any format accepted
+// by Parse. Canonical UUIDs are decoded with SIMD instructions where the
+// platform supports them. It stops at the first invalid input and returns
+// its error, including the index. It panics if dst is shorter than src.
+func ParseMany(dst []UUID, src []string) error {
+	dst = dst[:len(src)]
+	for i, s := range src {
+		if len(s) == 36 && parseCanonicalFast(&dst[i], s) {
+			continue
+		}
+		if err := parse(&dst[i], s); err != nil {
+			return fmt.Errorf("uuid: element %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// AppendCanonicalMany appends the canonical form of every UUID in src to
+// dst, separated by sep, and returns the extended buffer. The UUIDs are
+// encoded with SIMD instructions where the platform supports them.
+func AppendCanonicalMany(dst []byte, src []UUID, sep string) []byte {
+	if len(src) == 0 {
+		return dst
+	}
+	n := len(src)*36 + (len(src)-1)*len(sep)
+	if cap(dst)-len(dst) < n {
+		grown := make([]byte, len(dst), len(dst)+n)
+		copy(grown, dst)
+		dst = grown
+	}
+	for i := range src {
+		if i > 0 {
+			dst = append(dst, sep...)
+		}
+		l := len(dst)
+		dst = dst[:l+36]
+		encodeCanonicalFast((*[36]byte)(dst[l:]), &src[i])
+	}
+	return dst
+}
@@ -0,0 +1,47 @@
+//go:build amd64 && !purego
+
+package uuid
+
+// useSSSE3 reports whether the CPU supports the SSSE3 instructions used by
+// the batch fast paths.
+var useSSSE3 = hasSSSE3()
+
+// useAVX2 reports whether the CPU and OS support the AVX2 instructions used
+// by decodeCanonicalAVX2.
+var useAVX2 = hasAVX2()
+
+func hasSSSE3() bool
+
+func hasAVX2() bool
+
+//go:noescape
+func decodeCanonicalAVX2(u *UUID, s string) bool
+
+//go:noescape
+func decodeCanonicalSSSE3(u *UUID, s string) bool
+
+//go:noescape
+func encodeCanonicalSSSE3(dst *[36]byte, u *UUID)
+
+// parseCanonicalFast parses the 36 character canonical UUID s into u,
+// reporting whether s is valid. On failure, u is unchanged.
+func parseCanonicalFast(u *UUID, s string) bool {
+	if useAVX2 {
+		return decodeCanonicalAVX2(u, s)
+	}
+	if useSSSE3 {
+		return decodeCanonicalSSSE3(u, s)
+	}
+	return parse(u, s) == nil
+}
+
+// encodeCanonicalFast writes the canonical form of u to dst. There is no
+// AVX2 encoder: the digits are scattered across both 16-byte halves of the
+// output, which VPSHUFB cannot do within a 256-bit register.
+func encodeCanonicalFast(dst *[36]byte, u *UUID) {
+	if useSSSE3 {
+		encodeCanonicalSSSE3(dst, u)
+		return
+	}
+	encodeCanonical(dst[:], *u)
+}
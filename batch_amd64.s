@@ -0,0 +1,309 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// Shuffles gathering the 32 hex digits of a canonical UUID s. The first 16
+// digits are s[0:8], s[9:13] and s[14:18], taken from s[0:16] and s[16:32].
+// The last 16 are s[19:23] and s[24:36], taken from s[16:32] and s[20:36].
+DATA gatherA0<>+0(SB)/8, $0x0706050403020100
+DATA gatherA0<>+8(SB)/8, $0x80800f0e0c0b0a09
+GLOBL gatherA0<>(SB), RODATA|NOPTR, $16
+
+DATA gatherA1<>+0(SB)/8, $0x8080808080808080
+DATA gatherA1<>+8(SB)/8, $0x0100808080808080
+GLOBL gatherA1<>(SB), RODATA|NOPTR, $16
+
+DATA gatherB1<>+0(SB)/8, $0x8080808080808003
+DATA gatherB1<>+8(SB)/8, $0x8080808080808080
+GLOBL gatherB1<>(SB), RODATA|NOPTR, $16
+
+DATA gatherB2<>+0(SB)/8, $0x0706050402010080
+DATA gatherB2<>+8(SB)/8, $0x0f0e0d0c0b0a0908
+GLOBL gatherB2<>(SB), RODATA|NOPTR, $16
+
+// Shuffles and dashes scattering 32 hex digits into s[0:16] and s[16:32].
+// s[32:36] are the last 4 digits.
+DATA scatter0<>+0(SB)/8, $0x0706050403020100
+DATA scatter0<>+8(SB)/8, $0x0d0c800b0a090880
+GLOBL scatter0<>(SB), RODATA|NOPTR, $16
+
+DATA dashes0<>+0(SB)/8, $0x0000000000000000
+DATA dashes0<>+8(SB)/8, $0x00002d000000002d
+GLOBL dashes0<>(SB), RODATA|NOPTR, $16
+
+DATA scatter1a<>+0(SB)/8, $0x8080808080800f0e
+DATA scatter1a<>+8(SB)/8, $0x8080808080808080
+GLOBL scatter1a<>(SB), RODATA|NOPTR, $16
+
+DATA scatter1b<>+0(SB)/8, $0x8003020100808080
+DATA scatter1b<>+8(SB)/8, $0x0b0a090807060504
+GLOBL scatter1b<>(SB), RODATA|NOPTR, $16
+
+DATA dashes1<>+0(SB)/8, $0x2d000000002d0000
+DATA dashes1<>+8(SB)/8, $0x0000000000000000
+GLOBL dashes1<>(SB), RODATA|NOPTR, $16
+
+DATA hexDigits<>+0(SB)/8, $0x3736353433323130
+DATA hexDigits<>+8(SB)/8, $0x6665646362613938
+GLOBL hexDigits<>(SB), RODATA|NOPTR, $16
+
+DATA ascii0<>+0(SB)/8, $0x3030303030303030
+DATA ascii0<>+8(SB)/8, $0x3030303030303030
+GLOBL ascii0<>(SB), RODATA|NOPTR, $16
+
+DATA asciiA<>+0(SB)/8, $0x6161616161616161
+DATA asciiA<>+8(SB)/8, $0x6161616161616161
+GLOBL asciiA<>(SB), RODATA|NOPTR, $16
+
+DATA lower<>+0(SB)/8, $0x2020202020202020
+DATA lower<>+8(SB)/8, $0x2020202020202020
+GLOBL lower<>(SB), RODATA|NOPTR, $16
+
+DATA nine<>+0(SB)/8, $0x0909090909090909
+DATA nine<>+8(SB)/8, $0x0909090909090909
+GLOBL nine<>(SB), RODATA|NOPTR, $16
+
+DATA five<>+0(SB)/8, $0x0505050505050505
+DATA five<>+8(SB)/8, $0x0505050505050505
+GLOBL five<>(SB), RODATA|NOPTR, $16
+
+DATA ten<>+0(SB)/8, $0x0a0a0a0a0a0a0a0a
+DATA ten<>+8(SB)/8, $0x0a0a0a0a0a0a0a0a
+GLOBL ten<>(SB), RODATA|NOPTR, $16
+
+DATA nibble<>+0(SB)/8, $0x0f0f0f0f0f0f0f0f
+DATA nibble<>+8(SB)/8, $0x0f0f0f0f0f0f0f0f
+GLOBL nibble<>(SB), RODATA|NOPTR, $16
+
+// Weights combining pairs of nibbles into bytes with PMADDUBSW.
+DATA weights<>+0(SB)/8, $0x0110011001100110
+DATA weights<>+8(SB)/8, $0x0110011001100110
+GLOBL weights<>(SB), RODATA|NOPTR, $16
+
+// func hasSSSE3() bool
+TEXT ·hasSSSE3(SB), NOSPLIT, $0-1
+	MOVL $1, AX
+	XORL CX, CX
+	CPUID
+	SHRL $9, CX
+	ANDL $1, CX
+	MOVB CX, ret+0(FP)
+	RET
+
+// HEXDECODE converts the 16 hex digits in X to their values, and clears the
+// bytes of VALID for invalid digits. It uses X6, X7 and X8.
+#define HEXDECODE(X, VALID) \
+	MOVOU X, X6 \
+	PSUBB X9, X6       /* X6 = c - '0' */ \
+	MOVOU X6, X7 \
+	PMINUB X11, X7 \
+	PCMPEQB X6, X7     /* X7 = digits, c - '0' <= 9 */ \
+	POR X13, X \
+	PSUBB X10, X       /* X = (c | 0x20) - 'a' */ \
+	MOVOU X, X8 \
+	PMINUB X12, X8 \
+	PCMPEQB X, X8      /* X8 = letters, (c | 0x20) - 'a' <= 5 */ \
+	PADDB X14, X \
+	PAND X8, X \
+	PAND X7, X6 \
+	POR X6, X          /* X = value of the digit or letter */ \
+	POR X7, X8 \
+	PAND X8, VALID
+
+// func decodeCanonicalSSSE3(u *UUID, s string) bool
+TEXT ·decodeCanonicalSSSE3(SB), NOSPLIT, $0-25
+	MOVQ u+0(FP), DI
+	MOVQ s_base+8(FP), SI
+
+	CMPB 8(SI), $0x2d
+	JNE invalid
+	CMPB 13(SI), $0x2d
+	JNE invalid
+	CMPB 18(SI), $0x2d
+	JNE invalid
+	CMPB 23(SI), $0x2d
+	JNE invalid
+
+	MOVOU 0(SI), X0
+	MOVOU 16(SI), X1
+	MOVOU 20(SI), X2
+
+	MOVOU gatherA0<>(SB), X3
+	PSHUFB X3, X0
+	MOVOU X1, X4
+	MOVOU gatherA1<>(SB), X3
+	PSHUFB X3, X4
+	POR X4, X0             // X0 = digits 0-15
+
+	MOVOU gatherB1<>(SB), X3
+	PSHUFB X3, X1
+	MOVOU gatherB2<>(SB), X3
+	PSHUFB X3, X2
+	POR X2, X1             // X1 = digits 16-31
+
+	MOVOU ascii0<>(SB), X9
+	MOVOU asciiA<>(SB), X10
+	MOVOU nine<>(SB), X11
+	MOVOU five<>(SB), X12
+	MOVOU lower<>(SB), X13
+	MOVOU ten<>(SB), X14
+
+	PCMPEQB X5, X5         // X5 = all valid
+	HEXDECODE(X0, X5)
+	HEXDECODE(X1, X5)
+	PMOVMSKB X5, AX
+	CMPL AX, $0xffff
+	JNE invalid
+
+	MOVOU weights<>(SB), X3
+	PMADDUBSW X3, X0
+	PMADDUBSW X3, X1
+	PACKUSWB X1, X0
+	MOVOU X0, (DI)
+	MOVB $1, ret+24(FP)
+	RET
+
+invalid:
+	MOVB $0, ret+24(FP)
+	RET
+
+// func encodeCanonicalSSSE3(dst *[36]byte, u *UUID)
+TEXT ·encodeCanonicalSSSE3(SB), NOSPLIT, $0-16
+	MOVQ dst+0(FP), DI
+	MOVQ u+8(FP), SI
+
+	MOVOU (SI), X0
+	MOVOU nibble<>(SB), X5
+	MOVOU X0, X1
+	PSRLW $4, X1
+	PAND X5, X1            // X1 = high nibbles
+	PAND X5, X0            // X0 = low nibbles
+	MOVOU X1, X2
+	PUNPCKLBW X0, X2       // X2 = nibbles of bytes 0-7
+	PUNPCKHBW X0, X1       // X1 = nibbles of bytes 8-15
+
+	MOVOU hexDigits<>(SB), X3
+	MOVOU X3, X4
+	PSHUFB X2, X3          // X3 = digits 0-15
+	PSHUFB X1, X4          // X4 = digits 16-31
+
+	MOVOU X3, X0
+	MOVOU scatter0<>(SB), X5
+	PSHUFB X5, X0
+	MOVOU dashes0<>(SB), X5
+	POR X5, X0
+	MOVOU X0, 0(DI)
+
+	MOVOU scatter1a<>(SB), X5
+	PSHUFB X5, X3
+	MOVOU X4, X1
+	MOVOU scatter1b<>(SB), X5
+	PSHUFB X5, X1
+	POR X1, X3
+	MOVOU dashes1<>(SB), X5
+	POR X5, X3
+	MOVOU X3, 16(DI)
+
+	PSRLDQ $12, X4
+	MOVQ X4, AX
+	MOVL AX, 32(DI)
+	RET
+
+// Shuffles gathering the 32 hex digits of a canonical UUID s from s[0:32]
+// and from s[16:32] and s[20:36], the AVX2 form of gatherA0 to gatherB2.
+DATA gatherLo<>+0(SB)/8, $0x0706050403020100
+DATA gatherLo<>+8(SB)/8, $0x80800f0e0c0b0a09
+DATA gatherLo<>+16(SB)/8, $0x8080808080808003
+DATA gatherLo<>+24(SB)/8, $0x8080808080808080
+GLOBL gatherLo<>(SB), RODATA|NOPTR, $32
+
+DATA gatherHi<>+0(SB)/8, $0x8080808080808080
+DATA gatherHi<>+8(SB)/8, $0x0100808080808080
+DATA gatherHi<>+16(SB)/8, $0x0706050402010080
+DATA gatherHi<>+24(SB)/8, $0x0f0e0d0c0b0a0908
+GLOBL gatherHi<>(SB), RODATA|NOPTR, $32
+
+// func hasAVX2() bool
+TEXT ·hasAVX2(SB), NOSPLIT, $0-1
+	MOVL $1, AX
+	XORL CX, CX
+	CPUID
+	ANDL $0x18000000, CX   // OSXSAVE and AVX
+	CMPL CX, $0x18000000
+	JNE no
+	XORL CX, CX
+	XGETBV
+	ANDL $6, AX            // XMM and YMM state enabled by the OS
+	CMPL AX, $6
+	JNE no
+	MOVL $7, AX
+	XORL CX, CX
+	CPUID
+	SHRL $5, BX
+	ANDL $1, BX
+	MOVB BX, ret+0(FP)
+	RET
+
+no:
+	MOVB $0, ret+0(FP)
+	RET
+
+// func decodeCanonicalAVX2(u *UUID, s string) bool
+TEXT ·decodeCanonicalAVX2(SB), NOSPLIT, $0-25
+	MOVQ u+0(FP), DI
+	MOVQ s_base+8(FP), SI
+
+	CMPB 8(SI), $0x2d
+	JNE invalid
+	CMPB 13(SI), $0x2d
+	JNE invalid
+	CMPB 18(SI), $0x2d
+	JNE invalid
+	CMPB 23(SI), $0x2d
+	JNE invalid
+
+	VMOVDQU (SI), Y0
+	VMOVDQU 16(SI), X1
+	VINSERTI128 $1, 20(SI), Y1, Y1
+	VPSHUFB gatherLo<>(SB), Y0, Y0
+	VPSHUFB gatherHi<>(SB), Y1, Y1
+	VPOR Y1, Y0, Y0              // Y0 = digits 0-31
+
+	VPBROADCASTB ascii0<>(SB), Y9
+	VPBROADCASTB asciiA<>(SB), Y10
+	VPBROADCASTB nine<>(SB), Y11
+	VPBROADCASTB five<>(SB), Y12
+	VPBROADCASTB lower<>(SB), Y13
+	VPBROADCASTB ten<>(SB), Y14
+
+	VPSUBB Y9, Y0, Y6            // Y6 = c - '0'
+	VPMINUB Y11, Y6, Y7
+	VPCMPEQB Y6, Y7, Y7          // Y7 = digits, c - '0' <= 9
+	VPOR Y13, Y0, Y0
+	VPSUBB Y10, Y0, Y0           // Y0 = (c | 0x20) - 'a'
+	VPMINUB Y12, Y0, Y8
+	VPCMPEQB Y0, Y8, Y8          // Y8 = letters, (c | 0x20) - 'a' <= 5
+	VPADDB Y14, Y0, Y0
+	VPAND Y8, Y0, Y0
+	VPAND Y7, Y6, Y6
+	VPOR Y6, Y0, Y0              // Y0 = value of the digit or letter
+	VPOR Y7, Y8, Y8
+	VPMOVMSKB Y8, AX
+	CMPL AX, $0xffffffff
+	JNE invalidAVX
+
+	VPBROADCASTW weights<>(SB), Y3
+	VPMADDUBSW Y3, Y0, Y0
+	VEXTRACTI128 $1, Y0, X1
+	VPACKUSWB X1, X0, X0
+	VMOVDQU X0, (DI)
+	VZEROUPPER
+	MOVB $1, ret+24(FP)
+	RET
+
+invalidAVX:
+	VZEROUPPER
+
+invalid:
+	MOVB $0, ret+24(FP)
+	RET
@@ -0,0 +1,68 @@
+//go:build amd64 && !purego
+
+package uuid
+
+import "testing"
+
+// decodeCanonicalImpls are the amd64 decoders supported by this CPU.
+func decodeCanonicalImpls() map[string]func(*UUID, string) bool {
+	impls := make(map[string]func(*UUID, string) bool)
+	if useSSSE3 {
+		impls["SSSE3"] = decodeCanonicalSSSE3
+	}
+	if useAVX2 {
+		impls["AVX2"] = decodeCanonicalAVX2
+	}
+	return impls
+}
+
+// TestDecodeCanonicalAMD64 checks every decoder against Parse, not only the
+// one ParseMany picks, changing every character of a canonical UUID to
+// every byte value.
+func TestDecodeCanonicalAMD64(t *testing.T) {
+	base := []byte(codecTestUUID.String())
+	for name, decode := range decodeCanonicalImpls() {
+		t.Run(name, func(t *testing.T) {
+			for i := range base {
+				for c := 0; c < 256; c++ {
+					b := append([]byte(nil), base...)
+					b[i] = byte(c)
+					s := string(b)
+
+					var want UUID
+					werr := want.Parse(s)
+					got := Omni
+					ok := decode(&got, s)
+					if ok != (werr == nil) {
+						t.Fatalf("decode(%q) = %v, Parse error = %v", s, ok, werr)
+					}
+					if ok && got != want {
+						t.Fatalf("decode(%q) = %v, Parse = %v", s, got, want)
+					}
+					if !ok && got != Omni {
+						t.Fatalf("decode(%q) changed u to %v on error", s, got)
+					}
+				}
+			}
+			for i := 0; i < 1000; i++ {
+				u := randomTestUUID()
+				var got UUID
+				if !decode(&got, u.String()) || got != u {
+					t.Fatalf("decode(%v) = %v", u, got)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDecodeCanonicalAMD64(b *testing.B) {
+	src := benchmarkCanonicalStrings(1024)
+	for name, decode := range decodeCanonicalImpls() {
+		b.Run(name, func(b *testing.B) {
+			var u UUID
+			for i := 0; i < b.N; i++ {
+				decode(&u, src[i%len(src)])
+			}
+		})
+	}
+}
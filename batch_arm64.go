@@ -0,0 +1,20 @@
+//go:build arm64 && !purego
+
+package uuid
+
+//go:noescape
+func decodeCanonicalNEON(u *UUID, s string) bool
+
+//go:noescape
+func encodeCanonicalNEON(dst *[36]byte, u *UUID)
+
+// parseCanonicalFast parses the 36 character canonical UUID s into u,
+// reporting whether s is valid. On failure, u is unchanged.
+func parseCanonicalFast(u *UUID, s string) bool {
+	return decodeCanonicalNEON(u, s)
+}
+
+// encodeCanonicalFast writes the canonical form of u to dst.
+func encodeCanonicalFast(dst *[36]byte, u *UUID) {
+	encodeCanonicalNEON(dst, u)
+}
@@ -0,0 +1,139 @@
+//go:build arm64 && !purego
+
+#include "textflag.h"
+
+// Table indexes gathering the 32 hex digits of a canonical UUID s. The first
+// 16 digits are s[0:8], s[9:13] and s[14:18], taken from s[0:32]. The last
+// 16 are s[19:23] and s[24:36], taken from s[16:32] and s[20:36].
+DATA gather<>+0(SB)/8, $0x0706050403020100
+DATA gather<>+8(SB)/8, $0x11100f0e0c0b0a09
+DATA gather<>+16(SB)/8, $0x0b0a090806050403
+DATA gather<>+24(SB)/8, $0x1f1e1d1c0f0e0d0c
+GLOBL gather<>(SB), RODATA|NOPTR, $32
+
+// Table indexes scattering 32 hex digits into s[0:32], followed by the
+// dashes. Out of range indexes leave a zero byte for the dash.
+// s[32:36] are the last 4 digits.
+DATA scatter<>+0(SB)/8, $0x0706050403020100
+DATA scatter<>+8(SB)/8, $0x0d0cff0b0a0908ff
+DATA scatter<>+16(SB)/8, $0xff13121110ff0f0e
+DATA scatter<>+24(SB)/8, $0x1b1a191817161514
+DATA scatter<>+32(SB)/8, $0x0000000000000000
+DATA scatter<>+40(SB)/8, $0x00002d000000002d
+DATA scatter<>+48(SB)/8, $0x2d000000002d0000
+DATA scatter<>+56(SB)/8, $0x0000000000000000
+GLOBL scatter<>(SB), RODATA|NOPTR, $64
+
+DATA hexDigits<>+0(SB)/8, $0x3736353433323130
+DATA hexDigits<>+8(SB)/8, $0x6665646362613938
+GLOBL hexDigits<>(SB), RODATA|NOPTR, $16
+
+// func decodeCanonicalNEON(u *UUID, s string) bool
+TEXT ·decodeCanonicalNEON(SB), NOSPLIT, $0-25
+	MOVD u+0(FP), R0
+	MOVD s_base+8(FP), R1
+
+	MOVBU 8(R1), R2
+	CMP $0x2d, R2
+	BNE invalid
+	MOVBU 13(R1), R2
+	CMP $0x2d, R2
+	BNE invalid
+	MOVBU 18(R1), R2
+	CMP $0x2d, R2
+	BNE invalid
+	MOVBU 23(R1), R2
+	CMP $0x2d, R2
+	BNE invalid
+
+	VLD1 (R1), [V0.B16, V1.B16]
+	ADD $20, R1, R2
+	VLD1 (R2), [V2.B16]
+	MOVD $gather<>(SB), R3
+	VLD1 (R3), [V3.B16, V4.B16]
+	VTBL V3.B16, [V0.B16, V1.B16], V5.B16 // V5 = digits 0-15
+	VTBL V4.B16, [V1.B16, V2.B16], V6.B16 // V6 = digits 16-31
+
+	VMOVI $0x30, V16.B16
+	VMOVI $0x61, V17.B16
+	VMOVI $9, V18.B16
+	VMOVI $5, V19.B16
+	VMOVI $0x20, V20.B16
+	VMOVI $10, V21.B16
+	VMOVI $0xff, V10.B16 // V10 = all valid
+
+	VSUB V16.B16, V5.B16, V7.B16  // V7 = c - '0'
+	VUMIN V18.B16, V7.B16, V8.B16
+	VCMEQ V7.B16, V8.B16, V8.B16  // V8 = digits, c - '0' <= 9
+	VORR V20.B16, V5.B16, V5.B16
+	VSUB V17.B16, V5.B16, V5.B16  // V5 = (c | 0x20) - 'a'
+	VUMIN V19.B16, V5.B16, V9.B16
+	VCMEQ V5.B16, V9.B16, V9.B16  // V9 = letters, (c | 0x20) - 'a' <= 5
+	VADD V21.B16, V5.B16, V5.B16
+	VAND V9.B16, V5.B16, V5.B16
+	VAND V8.B16, V7.B16, V7.B16
+	VORR V7.B16, V5.B16, V5.B16   // V5 = value of the digit or letter
+	VORR V8.B16, V9.B16, V9.B16
+	VAND V9.B16, V10.B16, V10.B16
+
+	VSUB V16.B16, V6.B16, V7.B16
+	VUMIN V18.B16, V7.B16, V8.B16
+	VCMEQ V7.B16, V8.B16, V8.B16
+	VORR V20.B16, V6.B16, V6.B16
+	VSUB V17.B16, V6.B16, V6.B16
+	VUMIN V19.B16, V6.B16, V9.B16
+	VCMEQ V6.B16, V9.B16, V9.B16
+	VADD V21.B16, V6.B16, V6.B16
+	VAND V9.B16, V6.B16, V6.B16
+	VAND V8.B16, V7.B16, V7.B16
+	VORR V7.B16, V6.B16, V6.B16
+	VORR V8.B16, V9.B16, V9.B16
+	VAND V9.B16, V10.B16, V10.B16
+
+	VMOV V10.D[0], R4
+	VMOV V10.D[1], R5
+	AND R5, R4, R4
+	MVN R4, R4
+	CBNZ R4, invalid
+
+	VUZP1 V6.B16, V5.B16, V7.B16  // V7 = high nibbles
+	VUZP2 V6.B16, V5.B16, V8.B16  // V8 = low nibbles
+	VSHL $4, V7.B16, V7.B16
+	VORR V8.B16, V7.B16, V7.B16
+	VST1 [V7.B16], (R0)
+	MOVD $1, R2
+	MOVB R2, ret+24(FP)
+	RET
+
+invalid:
+	MOVB ZR, ret+24(FP)
+	RET
+
+// func encodeCanonicalNEON(dst *[36]byte, u *UUID)
+TEXT ·encodeCanonicalNEON(SB), NOSPLIT, $0-16
+	MOVD dst+0(FP), R0
+	MOVD u+8(FP), R1
+
+	VLD1 (R1), [V0.B16]
+	VMOVI $0x0f, V1.B16
+	VUSHR $4, V0.B16, V2.B16     // V2 = high nibbles
+	VAND V1.B16, V0.B16, V3.B16  // V3 = low nibbles
+	VZIP1 V3.B16, V2.B16, V4.B16 // V4 = nibbles of bytes 0-7
+	VZIP2 V3.B16, V2.B16, V5.B16 // V5 = nibbles of bytes 8-15
+
+	MOVD $hexDigits<>(SB), R2
+	VLD1 (R2), [V6.B16]
+	VTBL V4.B16, [V6.B16], V16.B16 // V16 = digits 0-15
+	VTBL V5.B16, [V6.B16], V17.B16 // V17 = digits 16-31
+
+	MOVD $scatter<>(SB), R2
+	VLD1 (R2), [V18.B16, V19.B16, V20.B16, V21.B16]
+	VTBL V18.B16, [V16.B16, V17.B16], V22.B16
+	VTBL V19.B16, [V16.B16, V17.B16], V23.B16
+	VORR V20.B16, V22.B16, V22.B16
+	VORR V21.B16, V23.B16, V23.B16
+	VST1 [V22.B16, V23.B16], (R0)
+
+	VMOV V17.S[3], R3
+	MOVW R3, 32(R0)
+	RET
@@ -0,0 +1,14 @@
+//go:build (!amd64 && !arm64) || purego
+
+package uuid
+
+// parseCanonicalFast parses the 36 character canonical UUID s into u,
+// reporting whether s is valid. On failure, u is unchanged.
+func parseCanonicalFast(u *UUID, s string) bool {
+	return parse(u, s) == nil
+}
+
+// encodeCanonicalFast writes the canonical form of u to dst.
+func encodeCanonicalFast(dst *[36]byte, u *UUID) {
+	encodeCanonical(dst[:], *u)
+}
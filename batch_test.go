@@ -0,0 +1,166 @@
+package uuid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMany(t *testing.T) {
+	t.Run("Formats", testParseManyFormats)
+	t.Run("MatchesParse", testParseManyMatchesParse)
+	t.Run("Error", testParseManyError)
+	t.Run("ShortDst", testParseManyShortDst)
+}
+
+func testParseManyFormats(t *testing.T) {
+	src := []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"EJ34kCVxxF9jHMKD4EgrAK",
+		"00000000-0000-0000-0000-000000000000",
+		"ffffffff-ffff-ffff-ffff-ffffffffffff",
+	}
+	want := []UUID{codecTestUUID, codecTestUUID, codecTestUUID, codecTestUUID, Nil, Omni}
+	dst := make([]UUID, len(src))
+	if err := ParseMany(dst, src); err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if dst[i] != want[i] {
+			t.Errorf("ParseMany(%q) = %v, want %v", src[i], dst[i], want[i])
+		}
+	}
+}
+
+// testParseManyMatchesParse checks the fast path against Parse, changing
+// every character of a canonical UUID to every byte value.
+func testParseManyMatchesParse(t *testing.T) {
+	base := []byte(codecTestUUID.String())
+	dst := make([]UUID, 1)
+	for i := range base {
+		for c := 0; c < 256; c++ {
+			b := append([]byte(nil), base...)
+			b[i] = byte(c)
+			s := string(b)
+
+			var want UUID
+			werr := want.Parse(s)
+			dst[0] = Omni
+			err := ParseMany(dst, []string{s})
+			if (err == nil) != (werr == nil) {
+				t.Fatalf("ParseMany(%q) error = %v, Parse error = %v", s, err, werr)
+			}
+			if err == nil && dst[0] != want {
+				t.Fatalf("ParseMany(%q) = %v, Parse = %v", s, dst[0], want)
+			}
+			if err != nil && dst[0] != Omni {
+				t.Fatalf("ParseMany(%q) changed dst to %v on error", s, dst[0])
+			}
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		u := randomTestUUID()
+		if err := ParseMany(dst, []string{u.String()}); err != nil || dst[0] != u {
+			t.Fatalf("ParseMany(%v) = %v, %v", u, dst[0], err)
+		}
+	}
+}
+
+func testParseManyError(t *testing.T) {
+	src := []string{codecTestUUID.String(), codecTestUUID.String(), "6ba7b810-9dad-11d1-80b4-00c04fd430cx"}
+	err := ParseMany(make([]UUID, 3), src)
+	if err == nil || !strings.Contains(err.Error(), "element 2") {
+		t.Errorf("ParseMany error = %v, want error for element 2", err)
+	}
+}
+
+func testParseManyShortDst(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("ParseMany with a short dst did not panic")
+		}
+	}()
+	ParseMany(make([]UUID, 1), []string{Nil.String(), Nil.String()})
+}
+
+func TestAppendCanonicalMany(t *testing.T) {
+	ids := make([]UUID, 100)
+	var want []string
+	for i := range ids {
+		ids[i] = randomTestUUID()
+		want = append(want, ids[i].String())
+	}
+	ids[0], want[0] = Nil, Nil.String()
+	ids[1], want[1] = Omni, Omni.String()
+
+	if got := string(AppendCanonicalMany(nil, ids, "\n")); got != strings.Join(want, "\n") {
+		t.Errorf("AppendCanonicalMany() = %q, want %q", got, strings.Join(want, "\n"))
+	}
+	got := AppendCanonicalMany([]byte("ids:"), ids[:2], ",")
+	if want := "ids:" + Nil.String() + "," + Omni.String(); string(got) != want {
+		t.Errorf("AppendCanonicalMany() = %q, want %q", got, want)
+	}
+	if got := AppendCanonicalMany(nil, nil, ","); got != nil {
+		t.Errorf("AppendCanonicalMany(nil) = %q, want nil", got)
+	}
+	buf := make([]byte, 0, 36)
+	if got := AppendCanonicalMany(buf, ids[:1], ""); &got[0] != &buf[:1][0] {
+		t.Error("AppendCanonicalMany reallocated a buffer with enough capacity")
+	}
+}
+
+func benchmarkCanonicalStrings(n int) []string {
+	src := make([]string, n)
+	for i := range src {
+		src[i] = randomTestUUID().String()
+	}
+	return src
+}
+
+// BenchmarkParseMany compares ParseMany with calling FromString for each
+// UUID, as BenchmarkFromString does. Results are per UUID.
+func BenchmarkParseMany(b *testing.B) {
+	src := benchmarkCanonicalStrings(1024)
+	dst := make([]UUID, len(src))
+	b.Run("ParseMany", func(b *testing.B) {
+		for i := 0; i < b.N; i += len(src) {
+			if err := ParseMany(dst, src); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("FromString", func(b *testing.B) {
+		for i := 0; i < b.N; i += len(src) {
+			for j, s := range src {
+				dst[j], _ = FromString(s)
+			}
+		}
+	})
+}
+
+// BenchmarkAppendCanonicalMany compares AppendCanonicalMany with appending
+// String for each UUID. Results are per UUID.
+func BenchmarkAppendCanonicalMany(b *testing.B) {
+	ids := make([]UUID, 1024)
+	for i := range ids {
+		ids[i] = randomTestUUID()
+	}
+	buf := make([]byte, 0, len(ids)*37)
+	b.Run("AppendCanonicalMany", func(b *testing.B) {
+		for i := 0; i < b.N; i += len(ids) {
+			buf = AppendCanonicalMany(buf[:0], ids, "\n")
+		}
+	})
+	b.Run("String", func(b *testing.B) {
+		for i := 0; i < b.N; i += len(ids) {
+			buf = buf[:0]
+			for j, u := range ids {
+				if j > 0 {
+					buf = append(buf, '\n')
+				}
+				buf = append(buf, u.String()...)
+			}
+		}
+	})
+}
@@ -21,6 +21,8 @@
 package uuid
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -84,59 +86,66 @@ var hashByteRange = [16]byte{
 // Parse parses the UUID stored in the string text. Parsing and supported
 // formats are the same as UnmarshalText.
 func (u *UUID) Parse(s string) error {
+	return parse(u, s)
+}
+
+// parse is the implementation of Parse and UnmarshalText, so both accept
+// the same input and return the same errors. The hex formats are unrolled
+// for speed. On error, u is unchanged.
+func parse[T string | []byte](u *UUID, s T) error {
+	var v UUID
 	switch len(s) {
 	case 22: // base58
-		if err := base58.UnmarshalString(u[:], s); err != nil {
+		if err := base58.UnmarshalBytes(v[:], []byte(s)); err != nil {
 			return err
 		}
-		return nil
 
 	case 32: // hash
 		// Unrolled hash parsing loop - 16 iterations, 2 chars per byte
-		v1 := hexLookupTable[s[0]]; v2 := hexLookupTable[s[1]]; if v1|v2 == 255 { return errInvalidFormat }; u[0] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[2]]; v2 = hexLookupTable[s[3]]; if v1|v2 == 255 { return errInvalidFormat }; u[1] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[4]]; v2 = hexLookupTable[s[5]]; if v1|v2 == 255 { return errInvalidFormat }; u[2] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[6]]; v2 = hexLookupTable[s[7]]; if v1|v2 == 255 { return errInvalidFormat }; u[3] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[8]]; v2 = hexLookupTable[s[9]]; if v1|v2 == 255 { return errInvalidFormat }; u[4] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[10]]; v2 = hexLookupTable[s[11]]; if v1|v2 == 255 { return errInvalidFormat }; u[5] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[12]]; v2 = hexLookupTable[s[13]]; if v1|v2 == 255 { return errInvalidFormat }; u[6] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[14]]; v2 = hexLookupTable[s[15]]; if v1|v2 == 255 { return errInvalidFormat }; u[7] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[16]]; v2 = hexLookupTable[s[17]]; if v1|v2 == 255 { return errInvalidFormat }; u[8] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[18]]; v2 = hexLookupTable[s[19]]; if v1|v2 == 255 { return errInvalidFormat }; u[9] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[20]]; v2 = hexLookupTable[s[21]]; if v1|v2 == 255 { return errInvalidFormat }; u[10] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[22]]; v2 = hexLookupTable[s[23]]; if v1|v2 == 255 { return errInvalidFormat }; u[11] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[24]]; v2 = hexLookupTable[s[25]]; if v1|v2 == 255 { return errInvalidFormat }; u[12] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[26]]; v2 = hexLookupTable[s[27]]; if v1|v2 == 255 { return errInvalidFormat }; u[13] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[28]]; v2 = hexLookupTable[s[29]]; if v1|v2 == 255 { return errInvalidFormat }; u[14] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[30]]; v2 = hexLookupTable[s[31]]; if v1|v2 == 255 { return errInvalidFormat }; u[15] = (v1 << 4) | v2
-		return nil
+		v1 := hexLookupTable[s[0]]; v2 := hexLookupTable[s[1]]; if v1|v2 == 255 { return errInvalidFormat }; v[0] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[2]]; v2 = hexLookupTable[s[3]]; if v1|v2 == 255 { return errInvalidFormat }; v[1] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[4]]; v2 = hexLookupTable[s[5]]; if v1|v2 == 255 { return errInvalidFormat }; v[2] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[6]]; v2 = hexLookupTable[s[7]]; if v1|v2 == 255 { return errInvalidFormat }; v[3] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[8]]; v2 = hexLookupTable[s[9]]; if v1|v2 == 255 { return errInvalidFormat }; v[4] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[10]]; v2 = hexLookupTable[s[11]]; if v1|v2 == 255 { return errInvalidFormat }; v[5] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[12]]; v2 = hexLookupTable[s[13]]; if v1|v2 == 255 { return errInvalidFormat }; v[6] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[14]]; v2 = hexLookupTable[s[15]]; if v1|v2 == 255 { return errInvalidFormat }; v[7] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[16]]; v2 = hexLookupTable[s[17]]; if v1|v2 == 255 { return errInvalidFormat }; v[8] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[18]]; v2 = hexLookupTable[s[19]]; if v1|v2 == 255 { return errInvalidFormat }; v[9] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[20]]; v2 = hexLookupTable[s[21]]; if v1|v2 == 255 { return errInvalidFormat }; v[10] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[22]]; v2 = hexLookupTable[s[23]]; if v1|v2 == 255 { return errInvalidFormat }; v[11] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[24]]; v2 = hexLookupTable[s[25]]; if v1|v2 == 255 { return errInvalidFormat }; v[12] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[26]]; v2 = hexLookupTable[s[27]]; if v1|v2 == 255 { return errInvalidFormat }; v[13] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[28]]; v2 = hexLookupTable[s[29]]; if v1|v2 == 255 { return errInvalidFormat }; v[14] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[30]]; v2 = hexLookupTable[s[31]]; if v1|v2 == 255 { return errInvalidFormat }; v[15] = (v1 << 4) | v2
 
 	case 36: // canonical
 		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
-			return fmt.Errorf("uuid: incorrect UUID format in string %q", s)
+			return fmt.Errorf("uuid: incorrect UUID format in string %q", string(s))
 		}
 		// Unrolled canonical parsing loop - canonicalByteRange: [0, 2, 4, 6, 9, 11, 14, 16, 19, 21, 24, 26, 28, 30, 32, 34]
-		v1 := hexLookupTable[s[0]]; v2 := hexLookupTable[s[1]]; if v1|v2 == 255 { return errInvalidFormat }; u[0] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[2]]; v2 = hexLookupTable[s[3]]; if v1|v2 == 255 { return errInvalidFormat }; u[1] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[4]]; v2 = hexLookupTable[s[5]]; if v1|v2 == 255 { return errInvalidFormat }; u[2] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[6]]; v2 = hexLookupTable[s[7]]; if v1|v2 == 255 { return errInvalidFormat }; u[3] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[9]]; v2 = hexLookupTable[s[10]]; if v1|v2 == 255 { return errInvalidFormat }; u[4] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[11]]; v2 = hexLookupTable[s[12]]; if v1|v2 == 255 { return errInvalidFormat }; u[5] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[14]]; v2 = hexLookupTable[s[15]]; if v1|v2 == 255 { return errInvalidFormat }; u[6] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[16]]; v2 = hexLookupTable[s[17]]; if v1|v2 == 255 { return errInvalidFormat }; u[7] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[19]]; v2 = hexLookupTable[s[20]]; if v1|v2 == 255 { return errInvalidFormat }; u[8] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[21]]; v2 = hexLookupTable[s[22]]; if v1|v2 == 255 { return errInvalidFormat }; u[9] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[24]]; v2 = hexLookupTable[s[25]]; if v1|v2 == 255 { return errInvalidFormat }; u[10] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[26]]; v2 = hexLookupTable[s[27]]; if v1|v2 == 255 { return errInvalidFormat }; u[11] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[28]]; v2 = hexLookupTable[s[29]]; if v1|v2 == 255 { return errInvalidFormat }; u[12] = (v1 << 4) | v2 
-		v1 = hexLookupTable[s[30]]; v2 = hexLookupTable[s[31]]; if v1|v2 == 255 { return errInvalidFormat }; u[13] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[32]]; v2 = hexLookupTable[s[33]]; if v1|v2 == 255 { return errInvalidFormat }; u[14] = (v1 << 4) | v2
-		v1 = hexLookupTable[s[34]]; v2 = hexLookupTable[s[35]]; if v1|v2 == 255 { return errInvalidFormat }; u[15] = (v1 << 4) | v2
-		return nil
+		v1 := hexLookupTable[s[0]]; v2 := hexLookupTable[s[1]]; if v1|v2 == 255 { return errInvalidFormat }; v[0] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[2]]; v2 = hexLookupTable[s[3]]; if v1|v2 == 255 { return errInvalidFormat }; v[1] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[4]]; v2 = hexLookupTable[s[5]]; if v1|v2 == 255 { return errInvalidFormat }; v[2] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[6]]; v2 = hexLookupTable[s[7]]; if v1|v2 == 255 { return errInvalidFormat }; v[3] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[9]]; v2 = hexLookupTable[s[10]]; if v1|v2 == 255 { return errInvalidFormat }; v[4] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[11]]; v2 = hexLookupTable[s[12]]; if v1|v2 == 255 { return errInvalidFormat }; v[5] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[14]]; v2 = hexLookupTable[s[15]]; if v1|v2 == 255 { return errInvalidFormat }; v[6] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[16]]; v2 = hexLookupTable[s[17]]; if v1|v2 == 255 { return errInvalidFormat }; v[7] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[19]]; v2 = hexLookupTable[s[20]]; if v1|v2 == 255 { return errInvalidFormat }; v[8] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[21]]; v2 = hexLookupTable[s[22]]; if v1|v2 == 255 { return errInvalidFormat }; v[9] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[24]]; v2 = hexLookupTable[s[25]]; if v1|v2 == 255 { return errInvalidFormat }; v[10] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[26]]; v2 = hexLookupTable[s[27]]; if v1|v2 == 255 { return errInvalidFormat }; v[11] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[28]]; v2 = hexLookupTable[s[29]]; if v1|v2 == 255 { return errInvalidFormat }; v[12] = (v1 << 4) | v2 
+		v1 = hexLookupTable[s[30]]; v2 = hexLookupTable[s[31]]; if v1|v2 == 255 { return errInvalidFormat }; v[13] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[32]]; v2 = hexLookupTable[s[33]]; if v1|v2 == 255 { return errInvalidFormat }; v[14] = (v1 << 4) | v2
+		v1 = hexLookupTable[s[34]]; v2 = hexLookupTable[s[35]]; if v1|v2 == 255 { return errInvalidFormat }; v[15] = (v1 << 4) | v2
 
 	default:
-		return fmt.Errorf("uuid: incorrect UUID length %d in string %q", len(s), s)
+		return fmt.Errorf("uuid: incorrect UUID length %d in string %q", len(s), string(s))
 	}
+	*u = v
+	return nil
 }
 
 // FromString returns a UUID parsed from the input string.
@@ -157,6 +166,58 @@ func FromStringOrNil(input string) UUID {
 	return uuid
 }
 
+// DetectFormat reports the format of the UUID encoded in s. It returns false
+// if s is not a valid UUID in any of the supported formats.
+func DetectFormat(s string) (Format, bool) {
+	switch len(s) {
+	case 22:
+		if base58.ValidString(s) {
+			return FormatBase58, true
+		}
+	case 32:
+		for i := 0; i < 32; i++ {
+			if hexLookupTable[s[i]] == 255 {
+				return "", false
+			}
+		}
+		return FormatHash, true
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return "", false
+		}
+		for _, x := range canonicalByteRange {
+			if hexLookupTable[s[x]]|hexLookupTable[s[x+1]] == 255 {
+				return "", false
+			}
+		}
+		return FormatCanonical, true
+	}
+	return "", false
+}
+
+// ParseAllowed returns a UUID parsed from the input string, but only if it is
+// encoded in one of the allowed formats. If no formats are given, every
+// supported format is allowed.
+func ParseAllowed(s string, allowed ...Format) (UUID, error) {
+	f, ok := DetectFormat(s)
+	if !ok {
+		return Nil, fmt.Errorf("uuid: incorrect UUID format in string %q", s)
+	}
+	if len(allowed) > 0 && !formatAllowed(f, allowed) {
+		return Nil, fmt.Errorf("uuid: %s format not allowed in string %q", f, s)
+	}
+	return FromString(s)
+}
+
+func formatAllowed(f Format, allowed []Format) bool {
+	for _, a := range allowed {
+		if a == f {
+			return true
+		}
+	}
+	return false
+}
+
 // MarshalText implements the encoding.TextMarshaler interface.
 // Creates a string representation of the UUID in the format specified by
 // DefaultFormat.
@@ -172,7 +233,7 @@ func (u UUID) MarshalText() ([]byte, error) {
 		return buf[:], nil
 	default:
 		var buf [22]byte
-		copy(buf[:], base58.Encode(u[:]))
+		base58.MarshalBytes(buf[:], u[:])
 		return buf[:], nil
 	}
 }
@@ -183,42 +244,94 @@ func (u UUID) MarshalText() ([]byte, error) {
 //	"6ba7b810-9dad-11d1-80b4-00c04fd430c8" (canonical)
 //	"6ba7b8109dad11d180b400c04fd430c8" (hash)
 //	"1C9z3nFjeJ44HMBeuqGNxt" (base58)
+//
+// Hex digits may be upper or lower case. On error, u is unchanged.
 func (u *UUID) UnmarshalText(b []byte) error {
-	switch len(b) {
-	case 22: // base58
-		if err := base58.UnmarshalBytes(u[:], b); err != nil {
-			return err
+	return parse(u, b)
+}
+
+// JSON decoding options for UUID.UnmarshalJSON and NullUUID.UnmarshalJSON.
+// Setting all of them to false gives the strictest decoding.
+var (
+	// NullJSONAsNil decodes JSON null to Nil. If false, null is an error.
+	// NullUUID always decodes null as an invalid NullUUID.
+	NullJSONAsNil = true
+
+	// EmptyJSONAsNil decodes the empty JSON string "" to Nil. If false, ""
+	// is an error.
+	EmptyJSONAsNil = false
+
+	// EmptyJSONAsNull makes NullUUID decode the empty JSON string "" like
+	// null, as an invalid NullUUID. It takes precedence over EmptyJSONAsNil.
+	EmptyJSONAsNull = false
+)
+
+var errEmptyJSON = errors.New("uuid: empty JSON string is not a valid UUID")
+
+// MarshalJSON implements the json.Marshaler interface.
+// Creates a quoted JSON string of the UUID in the format specified by
+// DefaultFormat.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	switch DefaultFormat {
+	case FormatCanonical:
+		var buf [38]byte
+		buf[0] = '"'
+		encodeCanonical(buf[1:], u)
+		buf[37] = '"'
+		return buf[:], nil
+	case FormatHash:
+		var buf [34]byte
+		buf[0] = '"'
+		encodeHash(buf[1:], u)
+		buf[33] = '"'
+		return buf[:], nil
+	default:
+		var buf [24]byte
+		buf[0] = '"'
+		base58.MarshalBytes(buf[1:], u[:])
+		buf[23] = '"'
+		return buf[:], nil
+	}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// The JSON string may be in any format supported by UnmarshalText and may
+// contain escape sequences. JSON null and the empty string are handled
+// according to NullJSONAsNil and EmptyJSONAsNil. On error, u is unchanged.
+func (u *UUID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		if !NullJSONAsNil {
+			return errors.New("uuid: JSON null is not a valid UUID")
 		}
+		*u = Nil
 		return nil
-
-	case 32: // hash
-		for i := 0; i < 32; i += 2 {
-			v1 := hexLookupTable[b[i]]
-			v2 := hexLookupTable[b[i+1]]
-			if v1|v2 == 255 {
-				return errInvalidFormat
-			}
-			u[i/2] = (v1 << 4) | v2
+	}
+	n := len(b)
+	if n < 2 || b[0] != '"' || b[n-1] != '"' {
+		return fmt.Errorf("uuid: cannot unmarshal JSON %s into UUID", b)
+	}
+	if n == 2 {
+		if !EmptyJSONAsNil {
+			return errEmptyJSON
 		}
+		*u = Nil
 		return nil
+	}
 
-	case 36: // canonical
-		if b[8] != '-' || b[13] != '-' || b[18] != '-' || b[23] != '-' {
-			return fmt.Errorf("uuid: incorrect UUID format in string %q", b)
+	var uu UUID
+	if bytes.IndexByte(b, '\\') >= 0 {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return fmt.Errorf("uuid: cannot unmarshal JSON %s into UUID: %w", b, err)
 		}
-		for i, x := range canonicalByteRange {
-			v1 := hexLookupTable[b[x]]
-			v2 := hexLookupTable[b[x+1]]
-			if v1|v2 == 255 {
-				return errInvalidFormat
-			}
-			u[i] = (v1 << 4) | v2
+		if err := uu.Parse(s); err != nil {
+			return err
 		}
-		return nil
-
-	default:
-		return fmt.Errorf("uuid: incorrect UUID length %d in string %q", len(b), b)
+	} else if err := uu.UnmarshalText(b[1 : n-1]); err != nil {
+		return err
 	}
+	*u = uu
+	return nil
 }
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
@@ -23,6 +23,8 @@ package uuid
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -114,8 +116,8 @@ func (fst fromStringTest) TestUnmarshalText(t *testing.T) {
 // fromStringTests contains UUID variants that are expected to be parsed
 // successfully by UnmarshalText / FromString.
 //
-// variants must be unique across elements of this slice. Please see the
-// comment in fuzz.go if you change this slice or add new tests to it.
+// variants must be unique across elements of this slice. addParseCorpus in
+// fuzz_test.go seeds the fuzz targets with its inputs.
 var fromStringTests = []fromStringTest{
 	{
 		input:   "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
@@ -181,6 +183,73 @@ func TestFromString(t *testing.T) {
 	})
 }
 
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Format
+	}{
+		{input: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", want: FormatCanonical},
+		{input: "6BA7B810-9DAD-11D1-80B4-00C04FD430C8", want: FormatCanonical},
+		{input: "6ba7b8109dad11d180b400c04fd430c8", want: FormatHash},
+		{input: "EJ34kCVxxF9jHMKD4EgrAK", want: FormatBase58},
+		{input: "1111111111111111111111", want: FormatBase58},
+	}
+	for _, tt := range tests {
+		got, ok := DetectFormat(tt.input)
+		if !ok || got != tt.want {
+			t.Errorf("DetectFormat(%q) = %q, %t, want %q, true", tt.input, got, ok, tt.want)
+		}
+	}
+
+	invalid := append([]string{
+		"0J34kCVxxF9jHMKD4EgrAK",
+		"zzzzzzzzzzzzzzzzzzzzzz",
+	}, invalidFromStringInputs...)
+	for _, s := range invalid {
+		if got, ok := DetectFormat(s); ok {
+			t.Errorf("DetectFormat(%q) = %q, true, want false", s, got)
+		}
+	}
+}
+
+func TestParseAllowed(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		for _, fst := range fromStringTests {
+			got, err := ParseAllowed(fst.input)
+			if err != nil {
+				t.Fatalf("ParseAllowed(%q): %v", fst.input, err)
+			}
+			if got != codecTestUUID {
+				t.Errorf("ParseAllowed(%q) = %v, want %v", fst.input, got, codecTestUUID)
+			}
+		}
+	})
+	t.Run("Restricted", func(t *testing.T) {
+		got, err := ParseAllowed("EJ34kCVxxF9jHMKD4EgrAK", FormatBase58)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != codecTestUUID {
+			t.Errorf("ParseAllowed(base58) = %v, want %v", got, codecTestUUID)
+		}
+		for _, s := range []string{
+			"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			"6ba7b8109dad11d180b400c04fd430c8",
+		} {
+			if got, err := ParseAllowed(s, FormatBase58); err == nil {
+				t.Errorf("ParseAllowed(%q, FormatBase58): want err != nil, got %v", s, got)
+			}
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range invalidFromStringInputs {
+			if got, err := ParseAllowed(s); err == nil {
+				t.Errorf("ParseAllowed(%q): want err != nil, got %v", s, got)
+			}
+		}
+	})
+}
+
 func TestFromStringOrNil(t *testing.T) {
 	t.Run("Invalid", func(t *testing.T) {
 		s := "bad"
@@ -349,6 +418,32 @@ func TestUnmarshalTextParseErrors(t *testing.T) {
 	}
 }
 
+// TestParseMatchesUnmarshalText checks that Parse and UnmarshalText agree on
+// the result and error for valid and invalid inputs.
+func TestParseMatchesUnmarshalText(t *testing.T) {
+	inputs := append([]string(nil), invalidFromStringInputs...)
+	for _, fst := range fromStringTests {
+		inputs = append(inputs, fst.input)
+	}
+	for _, tt := range parseErrorInputs {
+		inputs = append(inputs, tt.input)
+	}
+	for _, s := range inputs {
+		p, u := Omni, Omni
+		perr := p.Parse(s)
+		uerr := u.UnmarshalText([]byte(s))
+		if fmt.Sprint(perr) != fmt.Sprint(uerr) {
+			t.Errorf("input %q: Parse error %v, UnmarshalText error %v", s, perr, uerr)
+		}
+		if p != u {
+			t.Errorf("input %q: Parse = %v, UnmarshalText = %v", s, p, u)
+		}
+		if perr != nil && p != Omni {
+			t.Errorf("input %q: Parse changed the UUID to %v on error", s, p)
+		}
+	}
+}
+
 func TestMarshalBinary(t *testing.T) {
 	got, err := codecTestUUID.MarshalBinary()
 	if err != nil {
@@ -383,6 +478,73 @@ func TestMarshalTextDefaultFormat(t *testing.T) {
 	DefaultFormat = FormatCanonical
 }
 
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		f    Format
+		want string
+	}{
+		{f: FormatCanonical, want: `"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`},
+		{f: FormatHash, want: `"6ba7b8109dad11d180b400c04fd430c8"`},
+		{f: FormatBase58, want: `"EJ34kCVxxF9jHMKD4EgrAK"`},
+	}
+	defer func() { DefaultFormat = FormatCanonical }()
+	for _, tt := range tests {
+		DefaultFormat = tt.f
+		got, err := json.Marshal(codecTestUUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("json.Marshal(%v) with %s = %s, want %s", codecTestUUID, tt.f, got, tt.want)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		for _, fst := range fromStringTests {
+			var u UUID
+			data := []byte(`"` + fst.input + `"`)
+			if err := json.Unmarshal(data, &u); err != nil {
+				t.Fatalf("json.Unmarshal(%s): %v", data, err)
+			}
+			if u != codecTestUUID {
+				t.Errorf("json.Unmarshal(%s) = %v, want %v", data, u, codecTestUUID)
+			}
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, data := range []string{`257`, `"6ba7b810`, `6ba7b810-9dad-11d1-80b4-00c04fd430c8`, `"bad"`, `{}`} {
+			var u UUID
+			if err := u.UnmarshalJSON([]byte(data)); err == nil {
+				t.Errorf("UnmarshalJSON(%s): want err != nil, got %v", data, u)
+			}
+		}
+	})
+	t.Run("Null", func(t *testing.T) {
+		defer func() { NullJSONAsNil = true }()
+		u := codecTestUUID
+		if err := u.UnmarshalJSON([]byte(`null`)); err != nil || u != Nil {
+			t.Errorf("UnmarshalJSON(null) = %v, %v, want %v, <nil>", u, err, Nil)
+		}
+		NullJSONAsNil = false
+		if err := u.UnmarshalJSON([]byte(`null`)); err == nil {
+			t.Errorf("UnmarshalJSON(null) with NullJSONAsNil = false: want error")
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		defer func() { EmptyJSONAsNil = false }()
+		u := codecTestUUID
+		if err := u.UnmarshalJSON([]byte(`""`)); err == nil {
+			t.Errorf(`UnmarshalJSON("") = %v, want error`, u)
+		}
+		EmptyJSONAsNil = true
+		if err := u.UnmarshalJSON([]byte(`""`)); err != nil || u != Nil {
+			t.Errorf(`UnmarshalJSON("") with EmptyJSONAsNil = true = %v, %v, want %v, <nil>`, u, err, Nil)
+		}
+	})
+}
+
 func TestDecodePlainWithWrongLength(t *testing.T) {
 	arg := []byte{'4', '2'}
 
@@ -514,6 +676,45 @@ func BenchmarkUnmarshalText(b *testing.B) {
 	})
 }
 
+// parseErrorInputs are invalid inputs for each error path of the parser.
+var parseErrorInputs = []struct {
+	name  string
+	input string
+}{
+	{name: "length", input: "6ba7b810-9dad-11d1-80b4-00c04fd430c"},
+	{name: "dash", input: "6ba7b810x9dad-11d1-80b4-00c04fd430c8"},
+	{name: "canonical", input: "6ba7b810-9dad-11d1-80b4-00c04fd430cx"},
+	{name: "hash", input: "6ba7b8109dad11d180b400c04fd430cx"},
+	{name: "base58", input: "EJ34kCVxxF9jHMKD4Egr0K"},
+}
+
+func BenchmarkParseErrors(b *testing.B) {
+	for _, tt := range parseErrorInputs {
+		b.Run(tt.name, func(b *testing.B) {
+			var u UUID
+			for i := 0; i < b.N; i++ {
+				if u.Parse(tt.input) == nil {
+					b.Fatal("Parse did not fail")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkUnmarshalTextErrors(b *testing.B) {
+	for _, tt := range parseErrorInputs {
+		b.Run(tt.name, func(b *testing.B) {
+			var u UUID
+			text := []byte(tt.input)
+			for i := 0; i < b.N; i++ {
+				if u.UnmarshalText(text) == nil {
+					b.Fatal("UnmarshalText did not fail")
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkMarshalBinary(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		codecTestUUID.MarshalBinary()
@@ -0,0 +1,117 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+// Compare returns an integer comparing two UUIDs by their bytes, which is
+// the order of their canonical string forms and the creation order of v7
+// UUIDs. The result is 0 if a == b, -1 if a < b, and +1 if a > b. It can be
+// used in the less function of sort.Slice.
+func Compare(a, b UUID) int {
+	a0, b0 := binary.BigEndian.Uint64(a[:8]), binary.BigEndian.Uint64(b[:8])
+	if a0 != b0 {
+		if a0 < b0 {
+			return -1
+		}
+		return 1
+	}
+	a1, b1 := binary.BigEndian.Uint64(a[8:]), binary.BigEndian.Uint64(b[8:])
+	switch {
+	case a1 < b1:
+		return -1
+	case a1 > b1:
+		return 1
+	}
+	return 0
+}
+
+// Equal reports whether a and b are the same UUID. It is the same as a == b
+// and exists for use as a function value.
+func Equal(a, b UUID) bool {
+	return a == b
+}
+
+// Less reports whether u sorts before v, as defined by Compare.
+func (u UUID) Less(v UUID) bool {
+	return Compare(u, v) < 0
+}
+
+// radixSortThreshold is the slice length from which Sort uses a radix sort.
+// Below it, a comparison sort is faster.
+const radixSortThreshold = 256
+
+// Sort sorts a slice of UUIDs in increasing order, as defined by Compare.
+// Large slices are sorted with a radix sort, which allocates a copy of s.
+func Sort(s []UUID) {
+	if len(s) < radixSortThreshold {
+		sort.Sort(uuidSlice(s))
+		return
+	}
+	radixSort(s)
+}
+
+// IsSorted reports whether s is sorted in increasing order.
+func IsSorted(s []UUID) bool {
+	for i := 1; i < len(s); i++ {
+		if Compare(s[i-1], s[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// BinarySearch searches for u in the sorted slice s and returns the position
+// where u is found, or the position where it would be inserted, and whether
+// it was found.
+func BinarySearch(s []UUID, u UUID) (int, bool) {
+	i := sort.Search(len(s), func(i int) bool {
+		return Compare(s[i], u) >= 0
+	})
+	return i, i < len(s) && s[i] == u
+}
+
+// ContainsSorted reports whether u is in s, which must be sorted in
+// increasing order, as by Sort.
+func ContainsSorted(s []UUID, u UUID) bool {
+	_, ok := BinarySearch(s, u)
+	return ok
+}
+
+type uuidSlice []UUID
+
+func (s uuidSlice) Len() int           { return len(s) }
+func (s uuidSlice) Less(i, j int) bool { return Compare(s[i], s[j]) < 0 }
+func (s uuidSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// radixSort is an LSD radix sort over the 16 bytes of each UUID. Passes where
+// every UUID has the same byte, such as the version nibble of v7 UUIDs or
+// the timestamp of UUIDs created in the same millisecond, are skipped.
+func radixSort(s []UUID) {
+	buf := make([]UUID, len(s))
+	src, dst := s, buf
+	for b := Size - 1; b >= 0; b-- {
+		var count [256]int
+		for i := range src {
+			count[src[i][b]]++
+		}
+		if count[src[0][b]] == len(src) {
+			continue
+		}
+		pos := 0
+		for i, c := range count {
+			count[i] = pos
+			pos += c
+		}
+		for i := range src {
+			k := src[i][b]
+			dst[count[k]] = src[i]
+			count[k]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
@@ -0,0 +1,174 @@
+package uuid
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b UUID
+		want int
+	}{
+		{a: Nil, b: Nil, want: 0},
+		{a: Nil, b: Omni, want: -1},
+		{a: Omni, b: Nil, want: 1},
+		{a: codecTestUUID, b: codecTestUUID, want: 0},
+		{a: NamespaceDNS, b: NamespaceURL, want: -1},
+		{a: Must(FromString("00000000-0000-0000-0000-000000000002")), b: Must(FromString("00000000-0000-0000-0000-000000000001")), want: 1},
+		{a: Must(FromString("00000000-0000-0001-0000-000000000000")), b: Must(FromString("00000000-0000-0000-ffff-ffffffffffff")), want: 1},
+	}
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%v, %v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := tt.a.Less(tt.b); got != (tt.want < 0) {
+			t.Errorf("%v.Less(%v) = %t, want %t", tt.a, tt.b, got, tt.want < 0)
+		}
+		if got := Equal(tt.a, tt.b); got != (tt.want == 0) {
+			t.Errorf("Equal(%v, %v) = %t, want %t", tt.a, tt.b, got, tt.want == 0)
+		}
+	}
+}
+
+func TestCompareMatchesBytes(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		a, b := randomTestUUID(), randomTestUUID()
+		if i%3 == 0 {
+			copy(b[:8], a[:8])
+		}
+		if got, want := Compare(a, b), bytes.Compare(a[:], b[:]); got != want {
+			t.Fatalf("Compare(%v, %v) = %d, want %d", a, b, got, want)
+		}
+		if got, want := Compare(a, b), compareStrings(a.String(), b.String()); got != want {
+			t.Fatalf("Compare(%v, %v) = %d, string order %d", a, b, got, want)
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, radixSortThreshold - 1, radixSortThreshold, 5000} {
+		s := make([]UUID, n)
+		for i := range s {
+			s[i] = randomTestUUID()
+		}
+		want := append([]UUID(nil), s...)
+		sort.Slice(want, func(i, j int) bool { return bytes.Compare(want[i][:], want[j][:]) < 0 })
+
+		Sort(s)
+		if !IsSorted(s) {
+			t.Fatalf("Sort(%d UUIDs) is not sorted", n)
+		}
+		for i := range s {
+			if s[i] != want[i] {
+				t.Fatalf("Sort(%d UUIDs)[%d] = %v, want %v", n, i, s[i], want[i])
+			}
+		}
+	}
+}
+
+func TestSortV7(t *testing.T) {
+	g := newSteppingTestGen()
+	s := make([]UUID, 1000)
+	for i := range s {
+		s[i] = Must(g.NewV7())
+	}
+	want := append([]UUID(nil), s...)
+	rand.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+	Sort(s)
+	for i := range s {
+		if s[i] != want[i] {
+			t.Fatalf("Sort(v7)[%d] = %v, want creation order %v", i, s[i], want[i])
+		}
+	}
+}
+
+func TestIsSorted(t *testing.T) {
+	if !IsSorted(nil) || !IsSorted([]UUID{Omni}) || !IsSorted([]UUID{Nil, Nil, Omni}) {
+		t.Error("IsSorted() = false for sorted slice")
+	}
+	if IsSorted([]UUID{Omni, Nil}) {
+		t.Error("IsSorted() = true for unsorted slice")
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	s := []UUID{NamespaceDNS, NamespaceURL, NamespaceOID, NamespaceX500}
+	for i, u := range s {
+		got, ok := BinarySearch(s, u)
+		if got != i || !ok {
+			t.Errorf("BinarySearch(%v) = %d, %t, want %d, true", u, got, ok, i)
+		}
+		if !ContainsSorted(s, u) {
+			t.Errorf("ContainsSorted(%v) = false, want true", u)
+		}
+	}
+	for _, tt := range []struct {
+		u    UUID
+		want int
+	}{
+		{u: Nil, want: 0},
+		{u: Must(FromString("6ba7b813-0000-0000-0000-000000000000")), want: 3},
+		{u: Omni, want: 4},
+	} {
+		got, ok := BinarySearch(s, tt.u)
+		if got != tt.want || ok {
+			t.Errorf("BinarySearch(%v) = %d, %t, want %d, false", tt.u, got, ok, tt.want)
+		}
+		if ContainsSorted(s, tt.u) {
+			t.Errorf("ContainsSorted(%v) = true, want false", tt.u)
+		}
+	}
+}
+
+// newSteppingTestGen returns a Gen whose clock advances by one millisecond on
+// every call, so its v7 UUIDs sort in creation order by timestamp alone.
+func newSteppingTestGen() *Gen {
+	now := time.UnixMilli(1700000000000)
+	return NewGenWithOptions(WithEpochFunc(func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}))
+}
+
+func randomTestUUID() UUID {
+	var u UUID
+	rand.Read(u[:])
+	return u
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func BenchmarkSort(b *testing.B) {
+	for _, n := range []int{100, 10000} {
+		src := make([]UUID, n)
+		for i := range src {
+			src[i] = randomTestUUID()
+		}
+		s := make([]UUID, n)
+		b.Run(fmt.Sprintf("Sort/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(s, src)
+				Sort(s)
+			}
+		})
+		b.Run(fmt.Sprintf("sort.Slice/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(s, src)
+				sort.Slice(s, func(i, j int) bool { return bytes.Compare(s[i][:], s[j][:]) < 0 })
+			}
+		})
+	}
+}
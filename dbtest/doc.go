@@ -0,0 +1,4 @@
+// Package dbtest holds integration tests that run this module's SQL against
+// real database engines. It is a separate module so that the core package
+// doesn't depend on database drivers.
+package dbtest
@@ -0,0 +1,119 @@
+package dbtest
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/flexstack/uuid"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteUnixMs must match the timestamp expression used by uuid.SQLiteV7Expr.
+const sqliteUnixMs = "CAST(ROUND((julianday('now') - 2440587.5) * 86400000) AS INTEGER)"
+
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestSQLiteV7(t *testing.T) {
+	db := openSQLite(t)
+	seen := make(map[uuid.UUID]bool)
+	for i := 0; i < 1000; i++ {
+		var s string
+		var ms int64
+		if err := db.QueryRow("SELECT "+uuid.SQLiteV7Expr()+", "+sqliteUnixMs).Scan(&s, &ms); err != nil {
+			t.Fatal(err)
+		}
+		u, err := uuid.FromString(s)
+		if err != nil {
+			t.Fatalf("SQLiteV7Expr() returned %q: %v", s, err)
+		}
+		if c := u.Format(uuid.FormatCanonical); c != s {
+			t.Fatalf("SQLiteV7Expr() returned %q, want canonical form %q", s, c)
+		}
+		if u.Version() != uuid.V7 {
+			t.Fatalf("%v has version %d, want %d", u, u.Version(), uuid.V7)
+		}
+		if u.Variant() != uuid.VariantRFC4122 {
+			t.Fatalf("%v has variant %d, want %d", u, u.Variant(), uuid.VariantRFC4122)
+		}
+		ts, err := uuid.TimestampFromV7(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ts != ms {
+			t.Fatalf("TimestampFromV7(%v) = %d, want %d from SQLite", u, ts, ms)
+		}
+		if seen[u] {
+			t.Fatalf("duplicate UUID %v", u)
+		}
+		seen[u] = true
+	}
+}
+
+func TestSQLiteV7Clock(t *testing.T) {
+	db := openSQLite(t)
+	before := time.Now().UnixMilli()
+	var u uuid.UUID
+	if err := db.QueryRow("SELECT " + uuid.SQLiteV7Expr()).Scan(&u); err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().UnixMilli()
+	ts, err := uuid.TimestampFromV7(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts < before-1 || ts > after+1 {
+		t.Errorf("TimestampFromV7(%v) = %d, want between %d and %d", u, ts, before, after)
+	}
+}
+
+// TestSQLiteV7Interchangeable checks that IDs generated in Go and SQLite can
+// be stored in the same column and sort by creation time.
+func TestSQLiteV7Interchangeable(t *testing.T) {
+	db := openSQLite(t)
+	if _, err := db.Exec("CREATE TABLE ids (id TEXT PRIMARY KEY DEFAULT (" + uuid.SQLiteV7Expr() + "), n INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	for n := 0; n < 6; n++ {
+		if n%2 == 0 {
+			if _, err := db.Exec("INSERT INTO ids (id, n) VALUES (?, ?)", uuid.Must(uuid.NewV7()), n); err != nil {
+				t.Fatal(err)
+			}
+		} else if _, err := db.Exec("INSERT INTO ids (n) VALUES (?)", n); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	rows, err := db.Query("SELECT id, n FROM ids ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	want := 0
+	for rows.Next() {
+		var u uuid.UUID
+		var n int
+		if err := rows.Scan(&u, &n); err != nil {
+			t.Fatal(err)
+		}
+		if n != want {
+			t.Errorf("row %d ordered by id has n = %d (%v)", want, n, u)
+		}
+		want++
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if want != 6 {
+		t.Errorf("got %d rows, want 6", want)
+	}
+}
@@ -0,0 +1,195 @@
+package uuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// feistelRounds is the number of rounds of the Feistel network used by
+// Cipher.
+const feistelRounds = 8
+
+const mask61 = 1<<61 - 1
+
+var errNoCipher = errors.New("uuid: DefaultCipher is not set")
+
+// DefaultCipher is used by EncryptedV7 to encrypt and decrypt UUIDs.
+var DefaultCipher *Cipher
+
+// Cipher maps V7 UUIDs to V4-looking UUIDs and back with a secret key, so
+// public IDs don't reveal their creation time while the database keeps the
+// V7 UUIDs and their index locality.
+//
+// The 122 bits of a V7 UUID that are not version or variant are permuted by
+// a Feistel network with AES as its round function, and the result is
+// stored with the version and variant of a V4 UUID.
+//
+// Encryption is not authenticated: any V4 UUID decrypts to some V7 UUID, so
+// decrypted IDs must still be looked up.
+type Cipher struct {
+	block cipher.Block
+}
+
+// NewCipher returns a Cipher using key, which must be 16, 24 or 32 bytes to
+// select AES-128, AES-192 or AES-256.
+func NewCipher(key []byte) (*Cipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("uuid: %w", err)
+	}
+	return &Cipher{block: block}, nil
+}
+
+// Encrypt returns the V4 UUID that u, a V7 UUID, encrypts to.
+func (c *Cipher) Encrypt(u UUID) (UUID, error) {
+	if u.Version() != V7 || u.Variant() != VariantRFC4122 {
+		return Nil, fmt.Errorf("uuid: cannot encrypt version %d UUID, want version 7", u.Version())
+	}
+	l, r := feistelSplit(u)
+	var buf [16]byte
+	for i := 0; i < feistelRounds; i++ {
+		l, r = r, l^c.round(buf[:], i, r)
+	}
+	return feistelJoin(l, r, V4), nil
+}
+
+// Decrypt returns the V7 UUID that u, a V4 UUID, decrypts to.
+func (c *Cipher) Decrypt(u UUID) (UUID, error) {
+	if u.Version() != V4 || u.Variant() != VariantRFC4122 {
+		return Nil, fmt.Errorf("uuid: cannot decrypt version %d UUID, want version 4", u.Version())
+	}
+	l, r := feistelSplit(u)
+	var buf [16]byte
+	for i := feistelRounds - 1; i >= 0; i-- {
+		l, r = r^c.round(buf[:], i, l), l
+	}
+	return feistelJoin(l, r, V7), nil
+}
+
+// round is the round function of the Feistel network, returning 61 bits.
+func (c *Cipher) round(buf []byte, i int, x uint64) uint64 {
+	binary.BigEndian.PutUint64(buf[:8], uint64(i))
+	binary.BigEndian.PutUint64(buf[8:], x)
+	c.block.Encrypt(buf, buf)
+	return binary.BigEndian.Uint64(buf[:8]) & mask61
+}
+
+// feistelSplit returns the 122 bits of u that are not version or variant as
+// two 61-bit halves.
+func feistelSplit(u UUID) (l, r uint64) {
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	x := hi>>16<<12 | hi&0xfff // 60 bits
+	lo &= 1<<62 - 1
+	return x<<1 | lo>>61, lo & mask61
+}
+
+// feistelJoin is the inverse of feistelSplit, setting version v and the
+// RFC 4122 variant.
+func feistelJoin(l, r uint64, v byte) UUID {
+	x := l >> 1
+	hi := x>>12<<16 | uint64(v)<<12 | x&0xfff
+	lo := 1<<63 | (l&1)<<61 | r
+	var u UUID
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u
+}
+
+// EncryptedV7 is a V7 UUID that is encrypted with DefaultCipher when
+// marshaled to text or JSON and decrypted when unmarshaled, so it appears as
+// a V4 UUID in URLs and APIs. Value and Scan store the V7 UUID unencrypted.
+//
+// Nil is neither encrypted nor decrypted.
+type EncryptedV7 struct {
+	UUID
+}
+
+// Public returns the encrypted UUID.
+func (u EncryptedV7) Public() (UUID, error) {
+	if u.IsNil() {
+		return Nil, nil
+	}
+	if DefaultCipher == nil {
+		return Nil, errNoCipher
+	}
+	return DefaultCipher.Encrypt(u.UUID)
+}
+
+// setPublic sets u to the decryption of pub. On error, u is unchanged.
+func (u *EncryptedV7) setPublic(pub UUID) error {
+	if pub.IsNil() {
+		u.UUID = Nil
+		return nil
+	}
+	if DefaultCipher == nil {
+		return errNoCipher
+	}
+	uu, err := DefaultCipher.Decrypt(pub)
+	if err != nil {
+		return err
+	}
+	u.UUID = uu
+	return nil
+}
+
+// String returns the encrypted UUID in its canonical form, so formatted
+// output never shows the V7 timestamp. It returns a redacted placeholder if
+// DefaultCipher is not set.
+func (u EncryptedV7) String() string {
+	return u.Format(FormatCanonical)
+}
+
+// Format returns the encrypted UUID in the given format, or DefaultFormat.
+// It returns a redacted placeholder if DefaultCipher is not set.
+func (u EncryptedV7) Format(format ...Format) string {
+	pub, err := u.Public()
+	if err != nil {
+		return redacted
+	}
+	return pub.Format(format...)
+}
+
+// GoString returns the encrypted UUID for the %#v verb.
+func (u EncryptedV7) GoString() string {
+	return "uuid.EncryptedV7{" + u.String() + "}"
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u EncryptedV7) MarshalText() ([]byte, error) {
+	pub, err := u.Public()
+	if err != nil {
+		return nil, err
+	}
+	return pub.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *EncryptedV7) UnmarshalText(b []byte) error {
+	var pub UUID
+	if err := pub.UnmarshalText(b); err != nil {
+		return err
+	}
+	return u.setPublic(pub)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u EncryptedV7) MarshalJSON() ([]byte, error) {
+	pub, err := u.Public()
+	if err != nil {
+		return nil, err
+	}
+	return pub.MarshalJSON()
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *EncryptedV7) UnmarshalJSON(b []byte) error {
+	var pub UUID
+	if err := pub.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	return u.setPublic(pub)
+}
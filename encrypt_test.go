@@ -0,0 +1,242 @@
+package uuid
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var encryptTestKey = []byte("0123456789abcdef")
+
+func newTestCipher(t testing.TB) *Cipher {
+	c, err := NewCipher(encryptTestKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCipher(t *testing.T) {
+	t.Run("RoundTrip", testCipherRoundTrip)
+	t.Run("Vector", testCipherVector)
+	t.Run("Keys", testCipherKeys)
+	t.Run("WrongVersion", testCipherWrongVersion)
+	t.Run("InvalidKey", testCipherInvalidKey)
+}
+
+func testCipherRoundTrip(t *testing.T) {
+	c := newTestCipher(t)
+	for i := 0; i < 1000; i++ {
+		u := Must(NewV7())
+		enc, err := c.Encrypt(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if enc.Version() != V4 || enc.Variant() != VariantRFC4122 {
+			t.Fatalf("Encrypt(%v) = %v, want a V4 UUID", u, enc)
+		}
+		dec, err := c.Decrypt(enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dec != u {
+			t.Fatalf("Decrypt(Encrypt(%v)) = %v", u, dec)
+		}
+	}
+}
+
+func testCipherVector(t *testing.T) {
+	c := newTestCipher(t)
+	u := Must(FromString("01890a5d-ac96-774b-bcce-b302099a8057"))
+	want := Must(FromString("6361b35b-1c8b-4ef6-83fc-04bdbd9e3e96"))
+	enc, err := c.Encrypt(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enc != want {
+		t.Errorf("Encrypt(%v) = %v, want %v", u, enc, want)
+	}
+}
+
+func testCipherKeys(t *testing.T) {
+	c1 := newTestCipher(t)
+	c2, err := NewCipher([]byte("fedcba9876543210"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := Must(NewV7())
+	e1, _ := c1.Encrypt(u)
+	e2, _ := c2.Encrypt(u)
+	if e1 == e2 {
+		t.Errorf("%v encrypts to %v with different keys", u, e1)
+	}
+	v := u
+	v[15] ^= 1
+	if e, _ := c1.Encrypt(v); string(e[:6]) == string(e1[:6]) {
+		t.Errorf("encryptions of %v and %v share a prefix", u, v)
+	}
+}
+
+func testCipherWrongVersion(t *testing.T) {
+	c := newTestCipher(t)
+	if _, err := c.Encrypt(Must(NewV4())); err == nil {
+		t.Error("Encrypt of a V4 UUID did not fail")
+	}
+	if _, err := c.Decrypt(Must(NewV7())); err == nil {
+		t.Error("Decrypt of a V7 UUID did not fail")
+	}
+	if _, err := c.Encrypt(Nil); err == nil {
+		t.Error("Encrypt(Nil) did not fail")
+	}
+}
+
+func testCipherInvalidKey(t *testing.T) {
+	if _, err := NewCipher([]byte("short")); err == nil {
+		t.Error("NewCipher with a 5 byte key did not fail")
+	}
+}
+
+func TestEncryptedV7(t *testing.T) {
+	t.Run("Text", testEncryptedV7Text)
+	t.Run("JSON", testEncryptedV7JSON)
+	t.Run("Nil", testEncryptedV7Nil)
+	t.Run("NoCipher", testEncryptedV7NoCipher)
+	t.Run("Value", testEncryptedV7Value)
+	t.Run("Formatting", testEncryptedV7Formatting)
+}
+
+func withTestCipher(t *testing.T, c *Cipher) {
+	old := DefaultCipher
+	DefaultCipher = c
+	t.Cleanup(func() { DefaultCipher = old })
+}
+
+func testEncryptedV7Text(t *testing.T) {
+	c := newTestCipher(t)
+	withTestCipher(t, c)
+	u := EncryptedV7{Must(NewV7())}
+	b, err := u.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := c.Encrypt(u.UUID)
+	if string(b) != want.String() {
+		t.Errorf("MarshalText() = %s, want %v", b, want)
+	}
+	var got EncryptedV7
+	if err := got.UnmarshalText(b); err != nil {
+		t.Fatal(err)
+	}
+	if got != u {
+		t.Errorf("UnmarshalText(%s) = %v, want %v", b, got, u)
+	}
+	if err := got.UnmarshalText([]byte(u.UUID.String())); err == nil {
+		t.Error("UnmarshalText of the unencrypted V7 UUID did not fail")
+	}
+	if got.UUID != u.UUID {
+		t.Error("UnmarshalText changed the UUID on error")
+	}
+}
+
+func testEncryptedV7JSON(t *testing.T) {
+	withTestCipher(t, newTestCipher(t))
+	type record struct {
+		ID EncryptedV7 `json:"id"`
+	}
+	in := record{ID: EncryptedV7{Must(NewV7())}}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct{ ID UUID }
+	if err := json.Unmarshal(b, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw.ID.Version() != V4 {
+		t.Errorf("json.Marshal = %s, want a V4 UUID", b)
+	}
+	var out record
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", b, out.ID, in.ID)
+	}
+}
+
+func testEncryptedV7Nil(t *testing.T) {
+	withTestCipher(t, newTestCipher(t))
+	b, err := EncryptedV7{}.MarshalText()
+	if err != nil || string(b) != Nil.String() {
+		t.Errorf("MarshalText() = %s, %v, want %v", b, err, Nil)
+	}
+	u := EncryptedV7{Must(NewV7())}
+	if err := u.UnmarshalJSON([]byte("null")); err != nil || !u.IsNil() {
+		t.Errorf("UnmarshalJSON(null) = %v, %v, want Nil", u, err)
+	}
+}
+
+func testEncryptedV7NoCipher(t *testing.T) {
+	withTestCipher(t, nil)
+	if _, err := (EncryptedV7{Must(NewV7())}).MarshalText(); err != errNoCipher {
+		t.Errorf("MarshalText() error = %v, want %v", err, errNoCipher)
+	}
+	var u EncryptedV7
+	if err := u.UnmarshalText([]byte(Must(NewV4()).String())); err != errNoCipher {
+		t.Errorf("UnmarshalText() error = %v, want %v", err, errNoCipher)
+	}
+}
+
+func testEncryptedV7Value(t *testing.T) {
+	withTestCipher(t, newTestCipher(t))
+	u := EncryptedV7{Must(NewV7())}
+	v, err := u.Value()
+	if err != nil || v != u.UUID.String() {
+		t.Errorf("Value() = %v, %v, want the unencrypted %v", v, err, u.UUID)
+	}
+}
+
+// testEncryptedV7Formatting checks that formatted output shows the
+// encrypted UUID, as MarshalText does, and never the V7 UUID.
+func testEncryptedV7Formatting(t *testing.T) {
+	c := newTestCipher(t)
+	withTestCipher(t, c)
+	u := EncryptedV7{Must(NewV7())}
+	b, err := u.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, _ := c.Encrypt(u.UUID)
+	for _, tt := range []struct {
+		name, got, want string
+	}{
+		{"Sprint", fmt.Sprint(u), string(b)},
+		{"%s", fmt.Sprintf("%s", u), string(b)},
+		{"%v", fmt.Sprintf("%v", &u), string(b)},
+		{"%#v", fmt.Sprintf("%#v", u), "uuid.EncryptedV7{" + string(b) + "}"},
+		{"String", u.String(), string(b)},
+		{"Format", u.Format(FormatBase58), pub.Format(FormatBase58)},
+		{"Struct", fmt.Sprintf("%+v", struct{ ID EncryptedV7 }{u}), "{ID:" + string(b) + "}"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got, tt.want)
+		}
+		if strings.Contains(tt.got, u.UUID.String()) || strings.Contains(tt.got, u.UUID.Format(FormatBase58)) {
+			t.Errorf("%s = %s shows the V7 UUID", tt.name, tt.got)
+		}
+	}
+
+	withTestCipher(t, nil)
+	if got := fmt.Sprint(u); got != redacted {
+		t.Errorf("Sprint without a cipher = %s, want %s", got, redacted)
+	}
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	c := newTestCipher(b)
+	u := Must(NewV7())
+	for i := 0; i < b.N; i++ {
+		c.Encrypt(u)
+	}
+}
@@ -0,0 +1,110 @@
+package uuid
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// The cutoffs of the health tests assume a source with full entropy, 8 bits
+// per byte, and a false positive probability of 2^-40 per sample.
+const (
+	// rctCutoff is the number of identical consecutive bytes that fail the
+	// repetition count test: 1 + ceil(40/8).
+	rctCutoff = 6
+
+	// aptWindow is the window size of the adaptive proportion test.
+	aptWindow = 512
+
+	// aptCutoff is the number of occurrences of the first byte of a window
+	// that fail the adaptive proportion test: 1 + CRITBINOM(512, 2^-8, 1-2^-40).
+	aptCutoff = 19
+)
+
+var (
+	errRepetitionCount    = errors.New("uuid: entropy source failed the repetition count test")
+	errAdaptiveProportion = errors.New("uuid: entropy source failed the adaptive proportion test")
+)
+
+// HealthCheckReader wraps an entropy source with the continuous health tests
+// of NIST SP 800-90B, section 4.4: the repetition count test detects a
+// source stuck on one value, and the adaptive proportion test detects a
+// source that repeats some value far too often.
+//
+// Once a test fails, every Read fails, and bytes from the failing read are
+// never returned. Use it with WithRandomReader, so NewV4 and NewV7 return an
+// error instead of colliding UUIDs:
+//
+//	g := uuid.NewGenWithOptions(uuid.WithRandomReader(uuid.NewHealthCheckReader(r)))
+type HealthCheckReader struct {
+	r io.Reader
+
+	mu  sync.Mutex
+	err error
+
+	// repetition count test
+	last byte
+	run  int
+
+	// adaptive proportion test
+	first byte
+	count int
+	index int
+}
+
+// NewHealthCheckReader returns a HealthCheckReader reading from r.
+func NewHealthCheckReader(r io.Reader) *HealthCheckReader {
+	return &HealthCheckReader{r: r}
+}
+
+// Read implements the io.Reader interface.
+func (h *HealthCheckReader) Read(p []byte) (int, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.err != nil {
+		return 0, h.err
+	}
+	n, err := h.r.Read(p)
+	for _, b := range p[:n] {
+		if h.err = h.check(b); h.err != nil {
+			ZeroizeBytes(p[:n])
+			return 0, h.err
+		}
+	}
+	return n, err
+}
+
+// check runs both tests on the next byte of the stream.
+func (h *HealthCheckReader) check(b byte) error {
+	if h.run > 0 && b == h.last {
+		h.run++
+		if h.run >= rctCutoff {
+			return errRepetitionCount
+		}
+	} else {
+		h.last, h.run = b, 1
+	}
+
+	if h.index == 0 {
+		h.first, h.count = b, 1
+	} else if b == h.first {
+		h.count++
+		if h.count >= aptCutoff {
+			return errAdaptiveProportion
+		}
+	}
+	h.index++
+	if h.index == aptWindow {
+		h.index = 0
+	}
+	return nil
+}
+
+// Err returns the error of the failed health test, or nil if the source is
+// healthy.
+func (h *HealthCheckReader) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
@@ -0,0 +1,145 @@
+package uuid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+)
+
+// zeroReader is a stuck entropy source.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+// biasedReader returns random bytes, but every tenth byte is 0x42.
+type biasedReader struct {
+	n int
+}
+
+func (r *biasedReader) Read(p []byte) (int, error) {
+	if _, err := rand.Read(p); err != nil {
+		return 0, err
+	}
+	for i := range p {
+		if r.n%10 == 0 {
+			p[i] = 0x42
+		} else if p[i] == 0x42 {
+			p[i] = 0x43
+		}
+		r.n++
+	}
+	return len(p), nil
+}
+
+func TestHealthCheckReader(t *testing.T) {
+	t.Run("Healthy", testHealthCheckHealthy)
+	t.Run("RepetitionCount", testHealthCheckRepetitionCount)
+	t.Run("AdaptiveProportion", testHealthCheckAdaptiveProportion)
+	t.Run("Sticky", testHealthCheckSticky)
+	t.Run("Cutoffs", testHealthCheckCutoffs)
+}
+
+func testHealthCheckHealthy(t *testing.T) {
+	h := NewHealthCheckReader(rand.Reader)
+	buf := make([]byte, 1<<20)
+	if _, err := io.ReadFull(h, buf); err != nil {
+		t.Fatalf("crypto/rand failed a health test: %v", err)
+	}
+	if h.Err() != nil {
+		t.Errorf("Err() = %v", h.Err())
+	}
+}
+
+func testHealthCheckRepetitionCount(t *testing.T) {
+	g := NewGenWithOptions(WithRandomReader(NewHealthCheckReader(zeroReader{})))
+	if u, err := g.NewV4(); err != errRepetitionCount {
+		t.Errorf("NewV4() = %v, %v, want %v", u, err, errRepetitionCount)
+	}
+	g = NewGenWithOptions(WithRandomReader(NewHealthCheckReader(zeroReader{})))
+	if u, err := g.NewV7(); err != errRepetitionCount {
+		t.Errorf("NewV7() = %v, %v, want %v", u, err, errRepetitionCount)
+	}
+}
+
+func testHealthCheckAdaptiveProportion(t *testing.T) {
+	h := NewHealthCheckReader(&biasedReader{})
+	if _, err := io.ReadFull(h, make([]byte, aptWindow)); err != errAdaptiveProportion {
+		t.Errorf("biased source: error = %v, want %v", err, errAdaptiveProportion)
+	}
+}
+
+func testHealthCheckSticky(t *testing.T) {
+	h := NewHealthCheckReader(io.MultiReader(bytes.NewReader(make([]byte, rctCutoff)), rand.Reader))
+	buf := make([]byte, rctCutoff)
+	for i := range buf {
+		buf[i] = 0xff
+	}
+	if n, err := h.Read(buf); n != 0 || err != errRepetitionCount {
+		t.Fatalf("Read() = %d, %v, want 0, %v", n, err, errRepetitionCount)
+	}
+	if _, err := h.Read(buf); err != errRepetitionCount {
+		t.Errorf("Read() after a failure: error = %v, want %v", err, errRepetitionCount)
+	}
+	if h.Err() != errRepetitionCount {
+		t.Errorf("Err() = %v, want %v", h.Err(), errRepetitionCount)
+	}
+}
+
+func testHealthCheckCutoffs(t *testing.T) {
+	// A run one byte shorter than the cutoff passes.
+	run := append(make([]byte, rctCutoff-1), 1)
+	h := NewHealthCheckReader(bytes.NewReader(run))
+	if _, err := io.ReadFull(h, make([]byte, len(run))); err != nil {
+		t.Errorf("run of %d bytes: error = %v", rctCutoff-1, err)
+	}
+
+	// A window of 512 bytes passes with 18 occurrences of its first byte and
+	// fails with 19. They are spread out so the repetition count test passes.
+	for _, tt := range []struct {
+		n    int
+		want error
+	}{
+		{n: 18, want: nil},
+		{n: 19, want: errAdaptiveProportion},
+	} {
+		window := make([]byte, aptWindow)
+		for i := range window {
+			window[i] = byte(i%255) + 1
+		}
+		for i := 0; i < tt.n; i++ {
+			window[i*20] = 0
+		}
+		h = NewHealthCheckReader(bytes.NewReader(window))
+		if _, err := io.ReadFull(h, make([]byte, len(window))); err != tt.want {
+			t.Errorf("%d occurrences in a window: error = %v, want %v", tt.n, err, tt.want)
+		}
+	}
+}
+
+func TestWithRandomReader(t *testing.T) {
+	src := bytes.Repeat([]byte{0xff}, Size)
+	g := NewGenWithOptions(WithRandomReader(bytes.NewReader(src)))
+	u, err := g.NewV4()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ffffffff-ffff-4fff-bfff-ffffffffffff"; u.String() != want {
+		t.Errorf("NewV4() = %v, want %s", u, want)
+	}
+	if _, err := g.NewV4(); err == nil {
+		t.Error("NewV4() with an exhausted reader did not fail")
+	}
+}
+
+func BenchmarkHealthCheckReader(b *testing.B) {
+	g := NewGenWithOptions(WithRandomReader(NewHealthCheckReader(rand.Reader)))
+	for i := 0; i < b.N; i++ {
+		g.NewV4()
+	}
+}
@@ -0,0 +1,171 @@
+package uuid
+
+import (
+	"strings"
+	"testing"
+)
+
+// formats are the formats every parser must round-trip.
+var formats = []Format{FormatCanonical, FormatHash, FormatBase58}
+
+// addParseCorpus seeds f with the valid and invalid inputs of the table
+// tests, so fuzzing starts from every supported format and error path.
+func addParseCorpus(f *testing.F) {
+	for _, tt := range fromStringTests {
+		f.Add(tt.input)
+	}
+	for _, s := range invalidFromStringInputs {
+		f.Add(s)
+	}
+	for _, s := range []string{
+		"",
+		Nil.String(),
+		Omni.Format(FormatHash),
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"YcVfxkQb6JRzqk5kF2tNLv",
+		"YcVfxkQb6JRzqk5kF2tNLw",
+		"1C9z3nFjeJ44HMBeuqGNx\x80",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+	} {
+		f.Add(s)
+	}
+}
+
+// FuzzParse checks that Parse and UnmarshalText agree, that DetectFormat
+// accepts exactly what they accept, and that anything they accept formats
+// back to the input, ignoring hex case.
+func FuzzParse(f *testing.F) {
+	addParseCorpus(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		var p, u UUID
+		perr := p.Parse(s)
+		uerr := u.UnmarshalText([]byte(s))
+		if (perr == nil) != (uerr == nil) {
+			t.Fatalf("Parse(%q) error = %v, UnmarshalText error = %v", s, perr, uerr)
+		}
+		format, ok := DetectFormat(s)
+		if ok != (perr == nil) {
+			t.Fatalf("DetectFormat(%q) = %t, Parse error = %v", s, ok, perr)
+		}
+		if perr != nil {
+			return
+		}
+		if p != u {
+			t.Fatalf("Parse(%q) = %v, UnmarshalText = %v", s, p, u)
+		}
+		want := s
+		if format != FormatBase58 {
+			want = strings.ToLower(s)
+		}
+		if got := p.Format(format); got != want {
+			t.Fatalf("Parse(%q).Format(%s) = %q", s, format, got)
+		}
+	})
+}
+
+// FuzzFormat checks that every UUID round-trips through every Format, text
+// and binary marshaling.
+func FuzzFormat(f *testing.F) {
+	f.Add(Nil[:])
+	f.Add(Omni[:])
+	f.Add(codecTestData[:])
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var u UUID
+		if err := u.UnmarshalBinary(b); err != nil {
+			if len(b) == Size {
+				t.Fatalf("UnmarshalBinary(%x): %v", b, err)
+			}
+			return
+		}
+		for _, format := range formats {
+			s := u.Format(format)
+			got, err := FromString(s)
+			if err != nil || got != u {
+				t.Fatalf("FromString(%q) = %v, %v, want %v", s, got, err, u)
+			}
+			if err := got.UnmarshalText([]byte(s)); err != nil || got != u {
+				t.Fatalf("UnmarshalText(%q) = %v, %v, want %v", s, got, err, u)
+			}
+			if f, ok := DetectFormat(s); !ok || f != format {
+				t.Fatalf("DetectFormat(%q) = %s, %t, want %s", s, f, ok, format)
+			}
+		}
+		bin, err := u.MarshalBinary()
+		if err != nil || string(bin) != string(b) {
+			t.Fatalf("MarshalBinary() = %x, %v, want %x", bin, err, b)
+		}
+	})
+}
+
+// FuzzNullUUIDUnmarshalJSON checks that NullUUID.UnmarshalJSON never panics,
+// leaves its receiver unchanged on error, agrees with UUID.UnmarshalJSON on
+// non-null input, and that what it accepts round-trips through MarshalJSON.
+func FuzzNullUUIDUnmarshalJSON(f *testing.F) {
+	for _, s := range []string{
+		`null`,
+		`""`,
+		`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`"6ba7b8109dad11d180b400c04fd430c8"`,
+		`"EJ34kCVxxF9jHMKD4EgrAK"`,
+		`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`"6ba7b810-9dad-11d1-80b4-00c04fd430c8`,
+		`6ba7b810-9dad-11d1-80b4-00c04fd430c8`,
+		`"1C9z3nFjeJ44HMBeuqGNx\x80"`,
+		`{}`,
+		`42`,
+	} {
+		f.Add([]byte(s))
+	}
+	sentinel := NullUUID{UUID: Omni, Valid: true}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		u := sentinel
+		err := u.UnmarshalJSON(b)
+		if err != nil {
+			if u != sentinel {
+				t.Fatalf("UnmarshalJSON(%q) error %v changed the receiver to %v", b, err, u)
+			}
+		}
+		if string(b) != "null" && !(EmptyJSONAsNull && string(b) == `""`) {
+			var v UUID
+			verr := v.UnmarshalJSON(b)
+			if (err == nil) != (verr == nil) || (err == nil && v != u.UUID) {
+				t.Fatalf("UnmarshalJSON(%q): NullUUID = %v, %v, UUID = %v, %v", b, u, err, v, verr)
+			}
+		}
+		if err != nil {
+			return
+		}
+		out, err := u.MarshalJSON()
+		if err != nil {
+			t.Fatalf("MarshalJSON() of %v: %v", u, err)
+		}
+		var again NullUUID
+		if err := again.UnmarshalJSON(out); err != nil || again != u {
+			t.Fatalf("UnmarshalJSON(%s) = %v, %v, want %v", out, again, err, u)
+		}
+	})
+}
+
+// FuzzParseMany checks that ParseMany, and its SIMD fast path for canonical
+// UUIDs, agrees with Parse.
+func FuzzParseMany(f *testing.F) {
+	addParseCorpus(f)
+	f.Fuzz(func(t *testing.T, s string) {
+		var want UUID
+		werr := want.Parse(s)
+		dst := []UUID{Omni}
+		err := ParseMany(dst, []string{s})
+		if (err == nil) != (werr == nil) {
+			t.Fatalf("ParseMany(%q) error = %v, Parse error = %v", s, err, werr)
+		}
+		if err == nil && dst[0] != want {
+			t.Fatalf("ParseMany(%q) = %v, Parse = %v", s, dst[0], want)
+		}
+		if err != nil {
+			return
+		}
+		if got := string(AppendCanonicalMany(nil, dst, "")); got != want.String() {
+			t.Fatalf("AppendCanonicalMany(%v) = %q", want, got)
+		}
+	})
+}
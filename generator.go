@@ -23,6 +23,7 @@ package uuid
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"io"
 	"sync"
 	"time"
 )
@@ -75,6 +76,7 @@ type Gen struct {
 	clockSequenceOnce sync.Once
 	storageMutex      sync.Mutex
 	epochFunc         EpochFunc
+	rand              io.Reader
 	lastTime          uint64
 	clockSequence     uint16
 }
@@ -82,6 +84,23 @@ type Gen struct {
 // GenOption is a function type that can be used to configure a Gen generator.
 type GenOption func(*Gen)
 
+// WithEpochFunc sets the function that provides the current time. It
+// defaults to time.Now.
+func WithEpochFunc(f EpochFunc) GenOption {
+	return func(g *Gen) {
+		g.epochFunc = f
+	}
+}
+
+// WithRandomReader sets the source of random bytes. It defaults to
+// crypto/rand.Reader. Wrap it in a HealthCheckReader to detect a failing
+// source.
+func WithRandomReader(r io.Reader) GenOption {
+	return func(g *Gen) {
+		g.rand = r
+	}
+}
+
 // interface check -- build will fail if *Gen doesn't satisfy Generator
 var _ Generator = (*Gen)(nil)
 
@@ -90,13 +109,33 @@ var _ Generator = (*Gen)(nil)
 func NewGen() *Gen {
 	return &Gen{
 		epochFunc: time.Now,
+		rand:      rand.Reader,
 	}
 }
 
+// NewGenWithOptions returns a new instance of Gen with the options applied.
+func NewGenWithOptions(opts ...GenOption) *Gen {
+	g := NewGen()
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// readRandom fills b from the random source of g.
+func (g *Gen) readRandom(b []byte) error {
+	r := g.rand
+	if r == nil {
+		r = rand.Reader
+	}
+	_, err := io.ReadFull(r, b)
+	return err
+}
+
 // NewV4 returns a randomly generated UUID.
 func (g *Gen) NewV4() (UUID, error) {
 	u := UUID{}
-	if _, err := rand.Read(u[:]); err != nil {
+	if err := g.readRandom(u[:]); err != nil {
 		return Nil, err
 	}
 	u.SetVersion(V4)
@@ -113,7 +152,7 @@ func (g *Gen) getClockSequence(useUnixTSMs bool) (uint64, uint16, error) {
 	var err error
 	g.clockSequenceOnce.Do(func() {
 		buf := make([]byte, 2)
-		if _, err = rand.Read(buf); err != nil {
+		if err = g.readRandom(buf); err != nil {
 			return
 		}
 		g.clockSequence = binary.BigEndian.Uint16(buf)
@@ -131,10 +170,15 @@ func (g *Gen) getClockSequence(useUnixTSMs bool) (uint64, uint16, error) {
 	} else {
 		timeNow = g.getEpoch()
 	}
-	// Clock didn't change since last UUID generation.
-	// Should increase clock sequence.
+	// Clock didn't change since last UUID generation, or went backwards.
+	// Should increase clock sequence. NewV7 only keeps its low 12 bits, so
+	// move on to the next tick when they wrap to keep UUIDs in order.
 	if timeNow <= g.lastTime {
+		timeNow = g.lastTime
 		g.clockSequence++
+		if g.clockSequence&0x0fff == 0 {
+			timeNow++
+		}
 	}
 	g.lastTime = timeNow
 
@@ -185,7 +229,7 @@ func (g *Gen) NewV7() (UUID, error) {
 	u.SetVersion(V7)
 
 	//set rand_b 64bits of pseudo-random bits (first 2 will be overridden)
-	if _, err = rand.Read(u[8:16]); err != nil {
+	if err = g.readRandom(u[8:16]); err != nil {
 		return Nil, err
 	}
 	//override first 2 bits of byte[8] for the variant
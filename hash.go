@@ -0,0 +1,56 @@
+package uuid
+
+import "encoding/binary"
+
+// Hash64 returns a non-cryptographic 64-bit hash of u, suitable for hash
+// tables and sharding. It doesn't allocate.
+//
+// For V7 UUIDs the timestamp is left out and only the version, rand_a and
+// rand_b bits are hashed, so IDs created in the same millisecond or in
+// bursts spread as evenly as random ones.
+func (u UUID) Hash64() uint64 {
+	hi := binary.BigEndian.Uint64(u[:8])
+	lo := binary.BigEndian.Uint64(u[8:])
+	if u.Version() == V7 {
+		hi &= 0xffff
+	}
+	return fmix64(hi ^ fmix64(lo))
+}
+
+// Hash32 returns a non-cryptographic 32-bit hash of u. See Hash64.
+func (u UUID) Hash32() uint32 {
+	h := u.Hash64()
+	return uint32(h ^ h>>32)
+}
+
+// Shard returns the shard of u in [0, n), using JumpHash of Hash64. When n
+// grows, only about 1/n of the UUIDs move, all of them to the new shards.
+// It panics if n <= 0.
+func (u UUID) Shard(n int) int {
+	return JumpHash(u.Hash64(), n)
+}
+
+// JumpHash returns the bucket of key in [0, n) using the jump consistent hash
+// by Lamping and Veach. It panics if n <= 0.
+func JumpHash(key uint64, n int) int {
+	if n <= 0 {
+		panic("uuid: invalid number of buckets")
+	}
+	var b, j int64 = -1, 0
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
+
+// fmix64 is the MurmurHash3 finalizer.
+func fmix64(k uint64) uint64 {
+	k ^= k >> 33
+	k *= 0xff51afd7ed558ccd
+	k ^= k >> 33
+	k *= 0xc4ceb9fe1a85ec53
+	k ^= k >> 33
+	return k
+}
@@ -0,0 +1,150 @@
+package uuid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHash64(t *testing.T) {
+	t.Run("Deterministic", testHash64Deterministic)
+	t.Run("V7IgnoresTimestamp", testHash64V7IgnoresTimestamp)
+	t.Run("NoAllocs", testHash64NoAllocs)
+	t.Run("Distribution", testHash64Distribution)
+}
+
+func testHash64Deterministic(t *testing.T) {
+	u := codecTestUUID
+	if u.Hash64() != codecTestUUID.Hash64() || u.Hash32() != codecTestUUID.Hash32() {
+		t.Error("hash of equal UUIDs differs")
+	}
+	if Nil.Hash64() == Omni.Hash64() {
+		t.Error("Hash64(Nil) == Hash64(Omni)")
+	}
+	v := u
+	v[5] ^= 1
+	if u.Hash64() == v.Hash64() {
+		t.Errorf("Hash64 ignores byte 5 of a V%d UUID", u.Version())
+	}
+}
+
+func testHash64V7IgnoresTimestamp(t *testing.T) {
+	u := Must(NewV7())
+	v := u
+	v[0] ^= 0xff
+	v[5] ^= 0xff
+	if u.Hash64() != v.Hash64() {
+		t.Error("Hash64 of V7 UUIDs differs by timestamp")
+	}
+	v[15] ^= 1
+	if u.Hash64() == v.Hash64() {
+		t.Error("Hash64 of V7 UUIDs ignores rand_b")
+	}
+}
+
+func testHash64NoAllocs(t *testing.T) {
+	u := Must(NewV4())
+	if n := testing.AllocsPerRun(100, func() {
+		_ = u.Hash64()
+		_ = u.Hash32()
+		_ = u.Shard(16)
+	}); n != 0 {
+		t.Errorf("hashing allocates %v times", n)
+	}
+}
+
+func testHash64Distribution(t *testing.T) {
+	gens := []struct {
+		name string
+		fn   func() UUID
+	}{
+		{name: "V4", fn: func() UUID { return Must(NewV4()) }},
+		{name: "V7", fn: func() UUID { return Must(NewV7()) }},
+	}
+	for _, gen := range gens {
+		const n, buckets = 64000, 16
+		var shards, low [buckets]int
+		for i := 0; i < n; i++ {
+			u := gen.fn()
+			shards[u.Shard(buckets)]++
+			low[u.Hash32()%buckets]++
+		}
+		checkUniform(t, gen.name+" Shard", shards[:], n)
+		checkUniform(t, gen.name+" Hash32", low[:], n)
+	}
+}
+
+// checkUniform fails if the chi-squared statistic of counts exceeds the
+// 99.99th percentile for a uniform distribution.
+func checkUniform(t *testing.T, name string, counts []int, n int) {
+	t.Helper()
+	want := float64(n) / float64(len(counts))
+	var chi2 float64
+	for _, c := range counts {
+		d := float64(c) - want
+		chi2 += d * d / want
+	}
+	// Wilson-Hilferty approximation, z = 3.72 for p = 0.0001.
+	k := float64(len(counts) - 1)
+	limit := k * math.Pow(1-2/(9*k)+3.72*math.Sqrt(2/(9*k)), 3)
+	if chi2 > limit {
+		t.Errorf("%s: chi-squared = %.1f > %.1f, counts %v", name, chi2, limit, counts)
+	}
+}
+
+func TestJumpHash(t *testing.T) {
+	t.Run("Range", testJumpHashRange)
+	t.Run("Consistent", testJumpHashConsistent)
+	t.Run("InvalidBuckets", testJumpHashInvalidBuckets)
+}
+
+func testJumpHashRange(t *testing.T) {
+	for n := 1; n < 100; n++ {
+		for key := uint64(0); key < 100; key++ {
+			if b := JumpHash(key*0x9e3779b97f4a7c15, n); b < 0 || b >= n {
+				t.Fatalf("JumpHash(_, %d) = %d", n, b)
+			}
+		}
+	}
+}
+
+func testJumpHashConsistent(t *testing.T) {
+	const n = 10000
+	moved := 0
+	for i := 0; i < n; i++ {
+		u := Must(NewV4())
+		a, b := u.Shard(10), u.Shard(11)
+		if a != b {
+			if b != 10 {
+				t.Fatalf("%v moved from shard %d to %d, want 10", u, a, b)
+			}
+			moved++
+		}
+	}
+	// About 1/11 of the keys should move.
+	if moved < n/11/2 || moved > n/11*2 {
+		t.Errorf("%d of %d keys moved, want about %d", moved, n, n/11)
+	}
+}
+
+func testJumpHashInvalidBuckets(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("JumpHash(_, 0) did not panic")
+		}
+	}()
+	JumpHash(1, 0)
+}
+
+func BenchmarkHash64(b *testing.B) {
+	u := Must(NewV7())
+	for i := 0; i < b.N; i++ {
+		u.Hash64()
+	}
+}
+
+func BenchmarkShard(b *testing.B) {
+	u := Must(NewV7())
+	for i := 0; i < b.N; i++ {
+		u.Shard(64)
+	}
+}
@@ -0,0 +1,89 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+)
+
+var _ driver.Valuer = GUID{}
+var _ sql.Scanner = (*GUID)(nil)
+var _ encoding.BinaryMarshaler = GUID{}
+var _ encoding.BinaryUnmarshaler = (*GUID)(nil)
+
+// swapGUIDBytes converts between the RFC-4122 and the mixed-endian GUID byte
+// order. The conversion is its own inverse.
+func swapGUIDBytes(u UUID) UUID {
+	return UUID{
+		u[3], u[2], u[1], u[0],
+		u[5], u[4],
+		u[7], u[6],
+		u[8], u[9], u[10], u[11], u[12], u[13], u[14], u[15],
+	}
+}
+
+// FromGUIDBytes returns the UUID represented by b in the mixed-endian layout
+// used by SQL Server's uniqueidentifier and .NET's Guid.ToByteArray(), where
+// the first three groups are little-endian. It will return an error if the
+// slice isn't 16 bytes long.
+func FromGUIDBytes(b []byte) (UUID, error) {
+	u, err := FromBytes(b)
+	if err != nil {
+		return Nil, err
+	}
+	return swapGUIDBytes(u), nil
+}
+
+// ToGUIDBytes returns u in the mixed-endian layout used by SQL Server and
+// .NET. It is the inverse of FromGUIDBytes.
+func ToGUIDBytes(u UUID) []byte {
+	return swapGUIDBytes(u).Bytes()
+}
+
+// GUID is a UUID stored in a SQL Server uniqueidentifier column or exchanged
+// with .NET as Guid.ToByteArray(). The UUID holds the value as displayed by
+// SQL Server, while Value, Scan, Bytes, MarshalBinary and UnmarshalBinary
+// use the mixed-endian layout.
+type GUID struct {
+	UUID
+}
+
+// Bytes returns the mixed-endian byte representation of the GUID.
+func (g GUID) Bytes() []byte {
+	return ToGUIDBytes(g.UUID)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. It
+// returns the mixed-endian 16-byte form.
+func (g GUID) MarshalBinary() ([]byte, error) {
+	return g.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// reads the mixed-endian 16-byte form. On error, g is unchanged.
+func (g *GUID) UnmarshalBinary(data []byte) error {
+	u, err := FromGUIDBytes(data)
+	if err != nil {
+		return err
+	}
+	g.UUID = u
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It returns the mixed-endian
+// 16-byte form.
+func (g GUID) Value() (driver.Value, error) {
+	return g.Bytes(), nil
+}
+
+// Scan implements the sql.Scanner interface. A 16-byte slice or [16]byte
+// is read in the mixed-endian layout, while text and UUIDs are handled as by
+// UUID.Scan.
+func (g *GUID) Scan(src interface{}) error {
+	if b, ok := scanBinary(src); ok {
+		u, err := FromGUIDBytes(b)
+		g.UUID = u
+		return err
+	}
+	return g.UUID.Scan(src)
+}
@@ -0,0 +1,96 @@
+package uuid
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+// The output of .NET's new Guid("00112233-4455-6677-8899-aabbccddeeff").ToByteArray().
+var (
+	guidTestUUID  = Must(FromString("00112233-4455-6677-8899-aabbccddeeff"))
+	guidTestBytes = []byte{0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+)
+
+func TestGUIDBytes(t *testing.T) {
+	if got := ToGUIDBytes(guidTestUUID); !bytes.Equal(got, guidTestBytes) {
+		t.Errorf("ToGUIDBytes(%v) = %x, want %x", guidTestUUID, got, guidTestBytes)
+	}
+	got, err := FromGUIDBytes(guidTestBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != guidTestUUID {
+		t.Errorf("FromGUIDBytes(%x) = %v, want %v", guidTestBytes, got, guidTestUUID)
+	}
+	if _, err := FromGUIDBytes(guidTestBytes[:15]); err == nil {
+		t.Errorf("FromGUIDBytes(%x): want error for short input", guidTestBytes[:15])
+	}
+}
+
+func TestGUID(t *testing.T) {
+	t.Run("Bytes", func(t *testing.T) {
+		if got := (GUID{UUID: guidTestUUID}).Bytes(); !bytes.Equal(got, guidTestBytes) {
+			t.Errorf("Bytes() = %x, want %x", got, guidTestBytes)
+		}
+	})
+	t.Run("Value", func(t *testing.T) {
+		v, err := GUID{UUID: guidTestUUID}.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, ok := v.([]byte); !ok || !bytes.Equal(b, guidTestBytes) {
+			t.Errorf("Value() = %#v, want %x", v, guidTestBytes)
+		}
+	})
+	t.Run("Scan", func(t *testing.T) {
+		var array [Size]byte
+		copy(array[:], guidTestBytes)
+		text := guidTestUUID.String()
+		upper := strings.ToUpper(text)
+		u := guidTestUUID
+		for _, tt := range []struct {
+			name string
+			src  interface{}
+		}{
+			{name: "[]byte", src: guidTestBytes},
+			{name: "RawBytes", src: sql.RawBytes(guidTestBytes)},
+			{name: "[16]byte", src: array},
+			{name: "string", src: text},
+			{name: "string/upper", src: upper},
+			{name: "string/braced", src: "{" + text + "}"},
+			{name: "*string", src: &text},
+			{name: "[]byte/text", src: []byte(text)},
+			{name: "RawBytes/text", src: sql.RawBytes(text)},
+			{name: "UUID", src: u},
+			{name: "*UUID", src: &u},
+		} {
+			var got GUID
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("%s: Scan(%v): %v", tt.name, tt.src, err)
+			}
+			if got.UUID != guidTestUUID {
+				t.Errorf("%s: Scan(%v) = %v, want %v", tt.name, tt.src, got.UUID, guidTestUUID)
+			}
+		}
+	})
+	t.Run("Binary", func(t *testing.T) {
+		b, err := GUID{UUID: guidTestUUID}.MarshalBinary()
+		if err != nil || !bytes.Equal(b, guidTestBytes) {
+			t.Errorf("MarshalBinary() = %x, %v, want %x", b, err, guidTestBytes)
+		}
+		var g GUID
+		if err := g.UnmarshalBinary(guidTestBytes); err != nil || g.UUID != guidTestUUID {
+			t.Errorf("UnmarshalBinary(%x) = %v, %v, want %v", guidTestBytes, g.UUID, err, guidTestUUID)
+		}
+		if err := g.UnmarshalBinary(guidTestBytes[:15]); err == nil || g.UUID != guidTestUUID {
+			t.Errorf("UnmarshalBinary(%x) = %v, %v, want an error and no change", guidTestBytes[:15], g.UUID, err)
+		}
+	})
+	t.Run("String", func(t *testing.T) {
+		if got, want := (GUID{UUID: guidTestUUID}).String(), "00112233-4455-6677-8899-aabbccddeeff"; got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	})
+}
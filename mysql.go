@@ -0,0 +1,57 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+var _ driver.Valuer = MySQLSwapped{}
+var _ sql.Scanner = (*MySQLSwapped)(nil)
+
+// SwapTimeFields returns u with its time fields rearranged as done by
+// MySQL's UUID_TO_BIN(u, 1): time_hi_and_version first, then time_mid, then
+// time_low. For v1 UUIDs this puts the most significant time bits first,
+// which improves index locality.
+func SwapTimeFields(u UUID) UUID {
+	return UUID{
+		u[6], u[7],
+		u[4], u[5],
+		u[0], u[1], u[2], u[3],
+		u[8], u[9], u[10], u[11], u[12], u[13], u[14], u[15],
+	}
+}
+
+// UnswapTimeFields reverses SwapTimeFields, like MySQL's BIN_TO_UUID(b, 1).
+func UnswapTimeFields(u UUID) UUID {
+	return UUID{
+		u[4], u[5], u[6], u[7],
+		u[2], u[3],
+		u[0], u[1],
+		u[8], u[9], u[10], u[11], u[12], u[13], u[14], u[15],
+	}
+}
+
+// MySQLSwapped is a UUID stored in a MySQL BINARY(16) column with
+// UUID_TO_BIN(u, 1), i.e. with its time fields swapped.
+type MySQLSwapped struct {
+	UUID
+}
+
+// Value implements the driver.Valuer interface. It returns the swapped
+// 16-byte form.
+func (u MySQLSwapped) Value() (driver.Value, error) {
+	return ValueBinary(SwapTimeFields(u.UUID))
+}
+
+// Scan implements the sql.Scanner interface. A 16-byte slice or [16]byte
+// is unswapped, while text and UUIDs are handled as by UUID.Scan, since
+// BIN_TO_UUID(b, 1) returns the normal form.
+func (u *MySQLSwapped) Scan(src interface{}) error {
+	if b, ok := scanBinary(src); ok {
+		var uu UUID
+		copy(uu[:], b)
+		u.UUID = UnswapTimeFields(uu)
+		return nil
+	}
+	return u.UUID.Scan(src)
+}
@@ -0,0 +1,73 @@
+package uuid
+
+import (
+	"bytes"
+	"database/sql"
+	"strings"
+	"testing"
+)
+
+// From the MySQL reference manual for UUID_TO_BIN.
+var (
+	mysqlTestUUID    = Must(FromString("6ccd780c-baba-1026-9564-5b8c656024db"))
+	mysqlTestSwapped = []byte{0x10, 0x26, 0xba, 0xba, 0x6c, 0xcd, 0x78, 0x0c, 0x95, 0x64, 0x5b, 0x8c, 0x65, 0x60, 0x24, 0xdb}
+)
+
+func TestSwapTimeFields(t *testing.T) {
+	got := SwapTimeFields(mysqlTestUUID)
+	if !bytes.Equal(got[:], mysqlTestSwapped) {
+		t.Errorf("SwapTimeFields(%v) = %x, want %x", mysqlTestUUID, got[:], mysqlTestSwapped)
+	}
+	if back := UnswapTimeFields(got); back != mysqlTestUUID {
+		t.Errorf("UnswapTimeFields(%x) = %v, want %v", got[:], back, mysqlTestUUID)
+	}
+}
+
+func TestMySQLSwapped(t *testing.T) {
+	t.Run("Value", func(t *testing.T) {
+		v, err := MySQLSwapped{UUID: mysqlTestUUID}.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, ok := v.([]byte); !ok || !bytes.Equal(b, mysqlTestSwapped) {
+			t.Errorf("Value() = %#v, want %x", v, mysqlTestSwapped)
+		}
+	})
+	t.Run("Scan", func(t *testing.T) {
+		var array [Size]byte
+		copy(array[:], mysqlTestSwapped)
+		text := mysqlTestUUID.String()
+		upper := strings.ToUpper(text)
+		u := mysqlTestUUID
+		for _, tt := range []struct {
+			name string
+			src  interface{}
+		}{
+			{name: "[]byte", src: mysqlTestSwapped},
+			{name: "RawBytes", src: sql.RawBytes(mysqlTestSwapped)},
+			{name: "[16]byte", src: array},
+			{name: "string", src: text},
+			{name: "string/upper", src: upper},
+			{name: "string/braced", src: "{" + text + "}"},
+			{name: "*string", src: &text},
+			{name: "[]byte/text", src: []byte(text)},
+			{name: "RawBytes/text", src: sql.RawBytes(text)},
+			{name: "UUID", src: u},
+			{name: "*UUID", src: &u},
+		} {
+			var got MySQLSwapped
+			if err := got.Scan(tt.src); err != nil {
+				t.Fatalf("%s: Scan(%v): %v", tt.name, tt.src, err)
+			}
+			if got.UUID != mysqlTestUUID {
+				t.Errorf("%s: Scan(%v) = %v, want %v", tt.name, tt.src, got.UUID, mysqlTestUUID)
+			}
+		}
+	})
+	t.Run("Nil", func(t *testing.T) {
+		u := MySQLSwapped{UUID: mysqlTestUUID}
+		if err := u.Scan(nil); err != nil || !u.IsNil() {
+			t.Errorf("Scan(nil) = %v, %v, want Nil", u.UUID, err)
+		}
+	})
+}
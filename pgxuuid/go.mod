@@ -0,0 +1,12 @@
+module github.com/flexstack/uuid/pgxuuid
+
+go 1.19
+
+require (
+	github.com/flexstack/uuid v0.0.0
+	github.com/jackc/pgx/v5 v5.5.5
+)
+
+// Build against this checkout until the root module is tagged. Before
+// tagging pgxuuid, require that tag instead and remove this replace.
+replace github.com/flexstack/uuid => ../
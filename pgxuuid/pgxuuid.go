@@ -0,0 +1,155 @@
+// Package pgxuuid registers the types of github.com/flexstack/uuid with pgx,
+// so that UUIDs are encoded and decoded in PostgreSQL's 16-byte binary format
+// directly instead of going through their database/sql text representation.
+//
+// It is a separate module so that the core package doesn't depend on pgx.
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		pgxuuid.Register(conn.TypeMap())
+//		return nil
+//	}
+package pgxuuid
+
+import (
+	"fmt"
+
+	"github.com/flexstack/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Register registers Codec for the PostgreSQL uuid and uuid[] types in m, and
+// makes uuid.UUID, uuid.NullUUID and []uuid.UUID default to those types.
+func Register(m *pgtype.Map) {
+	t := &pgtype.Type{Name: "uuid", OID: pgtype.UUIDOID, Codec: Codec{}}
+	m.RegisterType(t)
+	m.RegisterType(&pgtype.Type{Name: "_uuid", OID: pgtype.UUIDArrayOID, Codec: &pgtype.ArrayCodec{ElementType: t}})
+
+	m.RegisterDefaultPgType(uuid.UUID{}, "uuid")
+	m.RegisterDefaultPgType(uuid.NullUUID{}, "uuid")
+	m.RegisterDefaultPgType([]uuid.UUID{}, "_uuid")
+}
+
+// Codec is the pgtype.Codec for the PostgreSQL uuid type. It encodes and scans
+// uuid.UUID and uuid.NullUUID directly and handles all other values like
+// pgtype.UUIDCodec.
+//
+// Scanning NULL into a uuid.UUID results in uuid.Nil, as with UUID.Scan.
+type Codec struct {
+	pgtype.UUIDCodec
+}
+
+// PlanEncode implements the pgtype.Codec interface.
+func (c Codec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	switch value.(type) {
+	case uuid.UUID, uuid.NullUUID:
+		switch format {
+		case pgtype.BinaryFormatCode:
+			return encodePlanBinary{}
+		case pgtype.TextFormatCode:
+			return encodePlanText{}
+		}
+	}
+	return c.UUIDCodec.PlanEncode(m, oid, format, value)
+}
+
+// PlanScan implements the pgtype.Codec interface.
+func (c Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	switch target.(type) {
+	case *uuid.UUID, *uuid.NullUUID:
+		switch format {
+		case pgtype.BinaryFormatCode:
+			return scanPlanBinary{}
+		case pgtype.TextFormatCode:
+			return scanPlanText{}
+		}
+	}
+	return c.UUIDCodec.PlanScan(m, oid, format, target)
+}
+
+// DecodeValue implements the pgtype.Codec interface. It returns a uuid.UUID,
+// or nil for NULL.
+func (c Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	var u uuid.UUID
+	if err := c.PlanScan(m, oid, format, &u).Scan(src, &u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// valueUUID returns the UUID held by value, or false if it is NULL.
+func valueUUID(value any) (uuid.UUID, bool) {
+	switch v := value.(type) {
+	case uuid.UUID:
+		return v, true
+	case uuid.NullUUID:
+		return v.UUID, v.Valid
+	}
+	return uuid.Nil, false
+}
+
+type encodePlanBinary struct{}
+
+func (encodePlanBinary) Encode(value any, buf []byte) ([]byte, error) {
+	u, ok := valueUUID(value)
+	if !ok {
+		return nil, nil
+	}
+	return append(buf, u[:]...), nil
+}
+
+type encodePlanText struct{}
+
+func (encodePlanText) Encode(value any, buf []byte) ([]byte, error) {
+	u, ok := valueUUID(value)
+	if !ok {
+		return nil, nil
+	}
+	return append(buf, u.String()...), nil
+}
+
+// setUUID stores u in dst, which is a *uuid.UUID or *uuid.NullUUID. A nil u
+// is NULL.
+func setUUID(dst any, u *uuid.UUID) error {
+	switch dst := dst.(type) {
+	case *uuid.UUID:
+		if u == nil {
+			*dst = uuid.Nil
+		} else {
+			*dst = *u
+		}
+	case *uuid.NullUUID:
+		*dst = uuid.NullUUIDFromPtr(u)
+	default:
+		return fmt.Errorf("pgxuuid: cannot scan into %T", dst)
+	}
+	return nil
+}
+
+type scanPlanBinary struct{}
+
+func (scanPlanBinary) Scan(src []byte, dst any) error {
+	if src == nil {
+		return setUUID(dst, nil)
+	}
+	u, err := uuid.FromBytes(src)
+	if err != nil {
+		return err
+	}
+	return setUUID(dst, &u)
+}
+
+type scanPlanText struct{}
+
+func (scanPlanText) Scan(src []byte, dst any) error {
+	if src == nil {
+		return setUUID(dst, nil)
+	}
+	var u uuid.UUID
+	if err := u.UnmarshalText(src); err != nil {
+		return err
+	}
+	return setUUID(dst, &u)
+}
@@ -0,0 +1,174 @@
+package pgxuuid
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/flexstack/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	testUUID   = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	testBinary = []byte{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	testText   = []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+)
+
+func newTestMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	Register(m)
+	return m
+}
+
+func TestEncode(t *testing.T) {
+	m := newTestMap()
+	tests := []struct {
+		name   string
+		format int16
+		value  any
+		want   []byte
+	}{
+		{name: "Binary", format: pgtype.BinaryFormatCode, value: testUUID, want: testBinary},
+		{name: "Text", format: pgtype.TextFormatCode, value: testUUID, want: testText},
+		{name: "Pointer", format: pgtype.BinaryFormatCode, value: &testUUID, want: testBinary},
+		{name: "NullValid", format: pgtype.BinaryFormatCode, value: uuid.NullUUID{UUID: testUUID, Valid: true}, want: testBinary},
+		{name: "NullInvalid", format: pgtype.BinaryFormatCode, value: uuid.NullUUID{}, want: nil},
+		{name: "NullInvalidText", format: pgtype.TextFormatCode, value: uuid.NullUUID{}, want: nil},
+	}
+	for _, tt := range tests {
+		got, err := m.Encode(pgtype.UUIDOID, tt.format, tt.value, nil)
+		if err != nil {
+			t.Fatalf("%s: Encode(%v): %v", tt.name, tt.value, err)
+		}
+		if !bytes.Equal(got, tt.want) || (got == nil) != (tt.want == nil) {
+			t.Errorf("%s: Encode(%v) = %x, want %x", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestScan(t *testing.T) {
+	m := newTestMap()
+	t.Run("UUID", func(t *testing.T) {
+		for _, src := range []struct {
+			format int16
+			data   []byte
+		}{
+			{format: pgtype.BinaryFormatCode, data: testBinary},
+			{format: pgtype.TextFormatCode, data: testText},
+		} {
+			var got uuid.UUID
+			if err := m.Scan(pgtype.UUIDOID, src.format, src.data, &got); err != nil {
+				t.Fatalf("Scan(%x): %v", src.data, err)
+			}
+			if got != testUUID {
+				t.Errorf("Scan(%x) = %v, want %v", src.data, got, testUUID)
+			}
+		}
+	})
+	t.Run("NullUUID", func(t *testing.T) {
+		var got uuid.NullUUID
+		if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, testBinary, &got); err != nil {
+			t.Fatal(err)
+		}
+		if want := (uuid.NullUUID{UUID: testUUID, Valid: true}); got != want {
+			t.Errorf("Scan(%x) = %+v, want %+v", testBinary, got, want)
+		}
+		if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Valid {
+			t.Errorf("Scan(NULL) = %+v, want invalid", got)
+		}
+	})
+	t.Run("Null", func(t *testing.T) {
+		got := testUUID
+		if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != uuid.Nil {
+			t.Errorf("Scan(NULL) = %v, want %v", got, uuid.Nil)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		var got uuid.UUID
+		if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, testBinary[:15], &got); err == nil {
+			t.Errorf("Scan(%x) = %v, want error", testBinary[:15], got)
+		}
+		if err := m.Scan(pgtype.UUIDOID, pgtype.TextFormatCode, []byte("bad"), &got); err == nil {
+			t.Errorf("Scan(bad) = %v, want error", got)
+		}
+	})
+	t.Run("PgtypeUUID", func(t *testing.T) {
+		var got pgtype.UUID
+		if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, testBinary, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !got.Valid || got.Bytes != testUUID {
+			t.Errorf("Scan(%x) = %v, want %v", testBinary, got, testUUID)
+		}
+	})
+}
+
+func TestArray(t *testing.T) {
+	m := newTestMap()
+	in := []uuid.UUID{testUUID, uuid.NamespaceURL}
+	for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+		data, err := m.Encode(pgtype.UUIDArrayOID, format, in, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out []uuid.UUID
+		if err := m.Scan(pgtype.UUIDArrayOID, format, data, &out); err != nil {
+			t.Fatalf("Scan(%x): %v", data, err)
+		}
+		if !reflect.DeepEqual(out, in) {
+			t.Errorf("format %d: round trip = %v, want %v", format, out, in)
+		}
+	}
+}
+
+func TestDecodeValue(t *testing.T) {
+	m := newTestMap()
+	typ, ok := m.TypeForOID(pgtype.UUIDOID)
+	if !ok {
+		t.Fatal("uuid type not registered")
+	}
+	v, err := typ.Codec.DecodeValue(m, pgtype.UUIDOID, pgtype.BinaryFormatCode, testBinary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != testUUID {
+		t.Errorf("DecodeValue(%x) = %#v, want %v", testBinary, v, testUUID)
+	}
+	if v, err := typ.Codec.DecodeValue(m, pgtype.UUIDOID, pgtype.BinaryFormatCode, nil); v != nil || err != nil {
+		t.Errorf("DecodeValue(NULL) = %v, %v, want nil, nil", v, err)
+	}
+}
+
+func TestDefaultType(t *testing.T) {
+	m := newTestMap()
+	for _, v := range []any{uuid.UUID{}, uuid.NullUUID{}} {
+		typ, ok := m.TypeForValue(v)
+		if !ok || typ.OID != pgtype.UUIDOID {
+			t.Errorf("TypeForValue(%T) = %v, want uuid", v, typ)
+		}
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	m := newTestMap()
+	b.Run("binary", func(b *testing.B) {
+		var u uuid.UUID
+		for i := 0; i < b.N; i++ {
+			m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, testBinary, &u)
+		}
+	})
+	b.Run("sql.Scanner", func(b *testing.B) {
+		m := pgtype.NewMap()
+		var u uuid.UUID
+		for i := 0; i < b.N; i++ {
+			m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, testBinary, &u)
+		}
+	})
+}
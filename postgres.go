@@ -0,0 +1,220 @@
+package uuid
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+var _ driver.Valuer = UUIDs{}
+var _ sql.Scanner = (*UUIDs)(nil)
+var _ driver.Valuer = NullUUIDs{}
+var _ sql.Scanner = (*NullUUIDs)(nil)
+
+// UUIDs is a slice of UUIDs that can be used with a PostgreSQL uuid[]
+// column, e.g. in "WHERE id = ANY($1)".
+type UUIDs []UUID
+
+// Value implements the driver.Valuer interface. A nil slice is NULL,
+// otherwise the slice is returned in the PostgreSQL array text format.
+func (a UUIDs) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	buf := make([]byte, 0, 2+len(a)*37)
+	buf = append(buf, '{')
+	for i, u := range a {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendCanonical(buf, u)
+	}
+	buf = append(buf, '}')
+	return string(buf), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts a PostgreSQL array
+// in text format. NULL elements are an error; use NullUUIDs for arrays that
+// may contain them.
+func (a *UUIDs) Scan(src interface{}) error {
+	text, err := arrayText(src)
+	if err != nil || text == nil {
+		*a = nil
+		return err
+	}
+	out := UUIDs{}
+	err = parseArray(text, func(elem []byte, null bool) error {
+		if null {
+			return fmt.Errorf("uuid: cannot scan NULL element into UUIDs")
+		}
+		var u UUID
+		if err := u.UnmarshalText(elem); err != nil {
+			return err
+		}
+		out = append(out, u)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*a = out
+	return nil
+}
+
+// NullUUIDs is a slice of NullUUIDs that can be used with a PostgreSQL
+// uuid[] column containing NULL elements.
+type NullUUIDs []NullUUID
+
+// Value implements the driver.Valuer interface. A nil slice is NULL,
+// otherwise the slice is returned in the PostgreSQL array text format with
+// invalid elements as NULL.
+func (a NullUUIDs) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	buf := make([]byte, 0, 2+len(a)*37)
+	buf = append(buf, '{')
+	for i, u := range a {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if u.Valid {
+			buf = appendCanonical(buf, u.UUID)
+		} else {
+			buf = append(buf, "NULL"...)
+		}
+	}
+	buf = append(buf, '}')
+	return string(buf), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts a PostgreSQL array
+// in text format.
+func (a *NullUUIDs) Scan(src interface{}) error {
+	text, err := arrayText(src)
+	if err != nil || text == nil {
+		*a = nil
+		return err
+	}
+	out := NullUUIDs{}
+	err = parseArray(text, func(elem []byte, null bool) error {
+		var u NullUUID
+		if !null {
+			if err := u.UUID.UnmarshalText(elem); err != nil {
+				return err
+			}
+			u.Valid = true
+		}
+		out = append(out, u)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*a = out
+	return nil
+}
+
+// appendCanonical appends the canonical RFC-4122 form of u to dst.
+func appendCanonical(dst []byte, u UUID) []byte {
+	var buf [36]byte
+	encodeCanonical(buf[:], u)
+	return append(dst, buf[:]...)
+}
+
+// arrayText returns the text of an array scanned from the database, or nil
+// for NULL.
+func arrayText(src interface{}) ([]byte, error) {
+	switch src := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return src, nil
+	case string:
+		return []byte(src), nil
+	}
+	return nil, fmt.Errorf("uuid: cannot convert %T to UUID array", src)
+}
+
+// parseArray parses a one-dimensional PostgreSQL array in text format, such
+// as {a,"b",NULL}, calling fn for each element. Quoted elements may contain
+// backslash escapes; an unquoted NULL is reported as null.
+func parseArray(b []byte, fn func(elem []byte, null bool) error) error {
+	if len(b) < 2 || b[0] != '{' || b[len(b)-1] != '}' {
+		return fmt.Errorf("uuid: invalid array %q", b)
+	}
+	s := b[1 : len(b)-1]
+	if len(bytes.TrimSpace(s)) == 0 {
+		return nil
+	}
+	for i := 0; ; {
+		for i < len(s) && isArraySpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '"' {
+			i++
+			start := i
+			var elem []byte
+			for i < len(s) && s[i] != '"' {
+				if s[i] == '\\' {
+					if elem == nil {
+						elem = append([]byte{}, s[start:i]...)
+					}
+					i++
+					if i == len(s) {
+						break
+					}
+				}
+				if elem != nil {
+					elem = append(elem, s[i])
+				}
+				i++
+			}
+			if i == len(s) {
+				return fmt.Errorf("uuid: unterminated quoted element in array %q", b)
+			}
+			if elem == nil {
+				elem = s[start:i]
+			}
+			i++
+			if err := fn(elem, false); err != nil {
+				return err
+			}
+		} else {
+			start := i
+			for i < len(s) && s[i] != ',' {
+				if s[i] == '{' || s[i] == '}' || s[i] == '"' || s[i] == '\\' {
+					return fmt.Errorf("uuid: invalid array %q", b)
+				}
+				i++
+			}
+			elem := bytes.TrimRight(s[start:i], " \t\n\r\v\f")
+			if len(elem) == 0 {
+				return fmt.Errorf("uuid: empty element in array %q", b)
+			}
+			if err := fn(elem, strings.EqualFold(string(elem), "NULL")); err != nil {
+				return err
+			}
+		}
+		for i < len(s) && isArraySpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return nil
+		}
+		if s[i] != ',' {
+			return fmt.Errorf("uuid: invalid array %q", b)
+		}
+		i++
+	}
+}
+
+func isArraySpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '\v', '\f':
+		return true
+	}
+	return false
+}
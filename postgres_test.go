@@ -0,0 +1,117 @@
+package uuid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUUIDs(t *testing.T) {
+	t.Run("Value", testUUIDsValue)
+	t.Run("Scan", func(t *testing.T) {
+		t.Run("Valid", testUUIDsScanValid)
+		t.Run("Invalid", testUUIDsScanInvalid)
+		t.Run("Nil", testUUIDsScanNil)
+	})
+}
+
+func testUUIDsValue(t *testing.T) {
+	tests := []struct {
+		a    UUIDs
+		want interface{}
+	}{
+		{a: nil, want: nil},
+		{a: UUIDs{}, want: "{}"},
+		{a: UUIDs{codecTestUUID}, want: "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"},
+		{a: UUIDs{codecTestUUID, Nil}, want: "{6ba7b810-9dad-11d1-80b4-00c04fd430c8,00000000-0000-0000-0000-000000000000}"},
+	}
+	for _, tt := range tests {
+		got, err := tt.a.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%v.Value() = %#v, want %#v", tt.a, got, tt.want)
+		}
+	}
+}
+
+func testUUIDsScanValid(t *testing.T) {
+	tests := []struct {
+		src  interface{}
+		want UUIDs
+	}{
+		{src: "{}", want: UUIDs{}},
+		{src: []byte("{ }"), want: UUIDs{}},
+		{src: "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}", want: UUIDs{codecTestUUID}},
+		{src: []byte(`{"6ba7b810-9dad-11d1-80b4-00c04fd430c8",6ba7b811-9dad-11d1-80b4-00c04fd430c8}`), want: UUIDs{codecTestUUID, NamespaceURL}},
+		{src: `{ 6ba7b810-9dad-11d1-80b4-00c04fd430c8 , "6ba7b811\-9dad-11d1-80b4-00c04fd430c8" }`, want: UUIDs{codecTestUUID, NamespaceURL}},
+	}
+	for _, tt := range tests {
+		var got UUIDs
+		if err := got.Scan(tt.src); err != nil {
+			t.Fatalf("Scan(%s): %v", tt.src, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Scan(%s) = %v, want %v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func testUUIDsScanInvalid(t *testing.T) {
+	inputs := []interface{}{
+		42,
+		"",
+		"{",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{NULL}",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8,}",
+		"{,6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		`{"6ba7b810-9dad-11d1-80b4-00c04fd430c8}`,
+		`{"6ba7b810-9dad-11d1-80b4-00c04fd430c8" x}`,
+		"{{6ba7b810-9dad-11d1-80b4-00c04fd430c8}}",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c}",
+	}
+	for _, src := range inputs {
+		var got UUIDs
+		if err := got.Scan(src); err == nil {
+			t.Errorf("Scan(%v) = %v, want error", src, got)
+		}
+	}
+}
+
+func testUUIDsScanNil(t *testing.T) {
+	got := UUIDs{codecTestUUID}
+	if err := got.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Errorf("Scan(nil) = %v, want nil", got)
+	}
+}
+
+func TestNullUUIDs(t *testing.T) {
+	a := NullUUIDs{{UUID: codecTestUUID, Valid: true}, {}}
+	v, err := a.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{6ba7b810-9dad-11d1-80b4-00c04fd430c8,NULL}"; v != want {
+		t.Errorf("%v.Value() = %#v, want %#v", a, v, want)
+	}
+
+	var got NullUUIDs
+	if err := got.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, a) {
+		t.Errorf("Scan(%v) = %v, want %v", v, got, a)
+	}
+
+	quoted := `{"NULL",null}`
+	if err := got.Scan(quoted); err == nil {
+		t.Errorf("Scan(%s) = %v, want error for quoted NULL", quoted, got)
+	}
+	if err := got.Scan(`{null}`); err != nil || len(got) != 1 || got[0].Valid {
+		t.Errorf("Scan({null}) = %v, %v, want one invalid element", got, err)
+	}
+}
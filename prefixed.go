@@ -0,0 +1,120 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// Prefix names the type prefix of a PrefixedID. Implementations are usually
+// empty struct types, which makes IDs with different prefixes distinct types:
+//
+//	type userPrefix struct{}
+//
+//	func (userPrefix) Prefix() string { return "usr" }
+//
+//	type UserID = uuid.PrefixedID[userPrefix]
+type Prefix interface {
+	Prefix() string
+}
+
+// PrefixedID is a UUID qualified by a type prefix, in the style of TypeID,
+// e.g. "usr_EJ34kCVxxF9jHMKD4EgrAK". The prefix is part of the type, so
+// parsing an ID with a different prefix is an error.
+//
+// Only the UUID is stored in a database; the prefix is implied by the column.
+type PrefixedID[P Prefix] struct {
+	UUID UUID
+}
+
+// prefixSeparator separates the prefix from the encoded UUID.
+const prefixSeparator = '_'
+
+// maxPrefixLen is the maximum length of a prefix, as in the TypeID spec.
+const maxPrefixLen = 63
+
+// ValidatePrefix reports whether prefix may be used as the prefix of a
+// PrefixedID. A prefix is 1 to 63 lowercase ASCII letters or underscores and
+// may not start or end with an underscore.
+func ValidatePrefix(prefix string) error {
+	if len(prefix) == 0 || len(prefix) > maxPrefixLen {
+		return fmt.Errorf("uuid: invalid prefix length %d in prefix %q", len(prefix), prefix)
+	}
+	if prefix[0] == prefixSeparator || prefix[len(prefix)-1] == prefixSeparator {
+		return fmt.Errorf("uuid: prefix %q must not start or end with %q", prefix, prefixSeparator)
+	}
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; (c < 'a' || c > 'z') && c != prefixSeparator {
+			return fmt.Errorf("uuid: invalid character %q in prefix %q", c, prefix)
+		}
+	}
+	return nil
+}
+
+// PrefixedIDFromString returns a PrefixedID parsed from the input string.
+// Input is expected in a form accepted by PrefixedID.Parse.
+func PrefixedIDFromString[P Prefix](s string) (PrefixedID[P], error) {
+	var id PrefixedID[P]
+	err := id.Parse(s)
+	return id, err
+}
+
+// Prefix returns the type prefix of the ID.
+func (id PrefixedID[P]) Prefix() string {
+	var p P
+	return p.Prefix()
+}
+
+// IsNil returns if the underlying UUID is equal to the nil UUID.
+func (id PrefixedID[P]) IsNil() bool {
+	return id.UUID.IsNil()
+}
+
+// String returns the prefix followed by an underscore and the base58
+// encoding of the UUID.
+func (id PrefixedID[P]) String() string {
+	return id.Prefix() + string(prefixSeparator) + id.UUID.Format(FormatBase58)
+}
+
+// Parse parses a prefixed ID. The prefix must match the ID's type prefix,
+// and the remainder may be in any format accepted by UUID.Parse.
+func (id *PrefixedID[P]) Parse(s string) error {
+	want := id.Prefix()
+	if err := ValidatePrefix(want); err != nil {
+		return err
+	}
+	i := strings.LastIndexByte(s, prefixSeparator)
+	if i < 0 {
+		return fmt.Errorf("uuid: missing prefix %q in string %q", want, s)
+	}
+	if got := s[:i]; got != want {
+		return fmt.Errorf("uuid: expected prefix %q, got %q in string %q", want, got, s)
+	}
+	return id.UUID.Parse(s[i+1:])
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (id PrefixedID[P]) MarshalText() ([]byte, error) {
+	if err := ValidatePrefix(id.Prefix()); err != nil {
+		return nil, err
+	}
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Input is expected in a form accepted by Parse.
+func (id *PrefixedID[P]) UnmarshalText(b []byte) error {
+	return id.Parse(string(b))
+}
+
+// Value implements the driver.Valuer interface. Only the UUID is stored, as
+// 16 bytes regardless of DefaultValueFunc.
+func (id PrefixedID[P]) Value() (driver.Value, error) {
+	return ValueBinary(id.UUID)
+}
+
+// Scan implements the sql.Scanner interface. The source is scanned as a
+// plain UUID, without a prefix.
+func (id *PrefixedID[P]) Scan(src interface{}) error {
+	return id.UUID.Scan(src)
+}
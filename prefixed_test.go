@@ -0,0 +1,155 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+type testUserPrefix struct{}
+
+func (testUserPrefix) Prefix() string { return "usr" }
+
+type testOrgPrefix struct{}
+
+func (testOrgPrefix) Prefix() string { return "org" }
+
+type testBadPrefix struct{}
+
+func (testBadPrefix) Prefix() string { return "Usr" }
+
+type testUserID = PrefixedID[testUserPrefix]
+
+func TestPrefixedID(t *testing.T) {
+	t.Run("String", testPrefixedIDString)
+	t.Run("Parse", func(t *testing.T) {
+		t.Run("Valid", testPrefixedIDParseValid)
+		t.Run("Invalid", testPrefixedIDParseInvalid)
+	})
+	t.Run("JSON", testPrefixedIDJSON)
+	t.Run("SQL", testPrefixedIDSQL)
+	t.Run("InvalidPrefix", testPrefixedIDInvalidPrefix)
+}
+
+func TestValidatePrefix(t *testing.T) {
+	valid := []string{"a", "usr", "api_key", "z_z"}
+	for _, p := range valid {
+		if err := ValidatePrefix(p); err != nil {
+			t.Errorf("ValidatePrefix(%q) = %v, want <nil>", p, err)
+		}
+	}
+	invalid := []string{
+		"",
+		"_usr",
+		"usr_",
+		"Usr",
+		"usr1",
+		"us-r",
+		"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijkl",
+	}
+	for _, p := range invalid {
+		if err := ValidatePrefix(p); err == nil {
+			t.Errorf("ValidatePrefix(%q) = <nil>, want error", p)
+		}
+	}
+}
+
+func testPrefixedIDString(t *testing.T) {
+	id := testUserID{UUID: codecTestUUID}
+	want := "usr_EJ34kCVxxF9jHMKD4EgrAK"
+	if got := id.String(); got != want {
+		t.Errorf("%v.String() = %q, want %q", id.UUID, got, want)
+	}
+}
+
+func testPrefixedIDParseValid(t *testing.T) {
+	inputs := []string{
+		"usr_EJ34kCVxxF9jHMKD4EgrAK",
+		"usr_6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"usr_6ba7b8109dad11d180b400c04fd430c8",
+	}
+	for _, s := range inputs {
+		id, err := PrefixedIDFromString[testUserPrefix](s)
+		if err != nil {
+			t.Fatalf("PrefixedIDFromString(%q): %v", s, err)
+		}
+		if id.UUID != codecTestUUID {
+			t.Errorf("PrefixedIDFromString(%q) = %v, want %v", s, id.UUID, codecTestUUID)
+		}
+	}
+}
+
+func testPrefixedIDParseInvalid(t *testing.T) {
+	inputs := []string{
+		"",
+		"EJ34kCVxxF9jHMKD4EgrAK",
+		"org_EJ34kCVxxF9jHMKD4EgrAK",
+		"usrEJ34kCVxxF9jHMKD4EgrAK",
+		"_EJ34kCVxxF9jHMKD4EgrAK",
+		"usr_usr_EJ34kCVxxF9jHMKD4EgrAK",
+		"usr_",
+		"usr_EJ34kCVxxF9jHMKD4Egr",
+	}
+	for _, s := range inputs {
+		var id testUserID
+		if err := id.Parse(s); err == nil {
+			t.Errorf("Parse(%q): want err != nil, got %v", s, id)
+		}
+	}
+}
+
+func testPrefixedIDJSON(t *testing.T) {
+	type payload struct {
+		User testUserID                `json:"user"`
+		Org  PrefixedID[testOrgPrefix] `json:"org"`
+	}
+	in := payload{
+		User: testUserID{UUID: codecTestUUID},
+		Org:  PrefixedID[testOrgPrefix]{UUID: NamespaceURL},
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out payload
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal(%s): %v", data, err)
+	}
+	if out != in {
+		t.Errorf("json round trip = %+v, want %+v", out, in)
+	}
+
+	swapped := []byte(`{"user":"org_EJ34kCVxxF9jHMKD4EgrAK"}`)
+	if err := json.Unmarshal(swapped, &out); err == nil {
+		t.Errorf("json.Unmarshal(%s): want error for wrong prefix", swapped)
+	}
+}
+
+func testPrefixedIDSQL(t *testing.T) {
+	id := testUserID{UUID: codecTestUUID}
+	v, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := v.([]byte); !ok || !bytes.Equal(b, codecTestData) {
+		t.Errorf("Value() = %#v, want %x", v, codecTestData)
+	}
+
+	var got testUserID
+	if err := got.Scan(codecTestData); err != nil {
+		t.Fatal(err)
+	}
+	if got != id {
+		t.Errorf("Scan(%x) = %v, want %v", codecTestData, got, id)
+	}
+}
+
+func testPrefixedIDInvalidPrefix(t *testing.T) {
+	id := PrefixedID[testBadPrefix]{UUID: codecTestUUID}
+	if _, err := id.MarshalText(); err == nil {
+		t.Errorf("MarshalText() with prefix %q: want error", id.Prefix())
+	}
+	if err := id.Parse("Usr_EJ34kCVxxF9jHMKD4EgrAK"); err == nil {
+		t.Errorf("Parse() with prefix %q: want error", id.Prefix())
+	}
+}
@@ -0,0 +1,133 @@
+package uuid
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"hash"
+	"testing"
+	"time"
+)
+
+// The test vectors of RFC 9562, appendices A and B. All of them use the
+// same time, Tuesday, February 22, 2022 2:22:22 PM GMT-05:00, and the name
+// "www.example.com" in the DNS namespace.
+var (
+	rfc9562Time = time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)
+	rfc9562Name = "www.example.com"
+)
+
+var rfc9562Vectors = []struct {
+	section string
+	uuid    string
+	version byte
+}{
+	{section: "A.1", uuid: "C232AB00-9414-11EC-B3C8-9F6BDECED846", version: V1},
+	{section: "A.2", uuid: "5df41881-3aed-3515-88a7-2f4a814cf09e", version: V3},
+	{section: "A.3", uuid: "919108f7-52d1-4320-9bac-f847db4148a8", version: V4},
+	{section: "A.4", uuid: "2ed6657d-e927-568b-95e1-2665a8aea6a2", version: V5},
+	{section: "A.5", uuid: "1EC9414C-232A-6B00-B3C8-9F6BDECED846", version: V6},
+	{section: "A.6", uuid: "017F22E2-79B0-7CC3-98C4-DC0C0C07398F", version: V7},
+	{section: "B.1", uuid: "2489E9AD-2EE2-8E00-8EC9-32D5F69181C0", version: 8},
+	{section: "B.2", uuid: "5c146b14-3c52-8afd-938a-375d0df1fbf6", version: 8},
+}
+
+func TestRFC9562(t *testing.T) {
+	t.Run("Fields", testRFC9562Fields)
+	t.Run("TimestampFromV7", testRFC9562TimestampFromV7)
+	t.Run("V1V6Fields", testRFC9562V1V6Fields)
+	t.Run("NameBased", testRFC9562NameBased)
+	t.Run("SpecialValues", testRFC9562SpecialValues)
+}
+
+func testRFC9562Fields(t *testing.T) {
+	for _, tt := range rfc9562Vectors {
+		u, err := FromString(tt.uuid)
+		if err != nil {
+			t.Fatalf("%s: FromString(%q): %v", tt.section, tt.uuid, err)
+		}
+		if got := u.Version(); got != tt.version {
+			t.Errorf("%s: Version() = %d, want %d", tt.section, got, tt.version)
+		}
+		if got := u.Variant(); got != VariantRFC4122 {
+			t.Errorf("%s: Variant() = %d, want %d", tt.section, got, VariantRFC4122)
+		}
+	}
+}
+
+func testRFC9562TimestampFromV7(t *testing.T) {
+	ms, err := TimestampFromV7(Must(FromString("017F22E2-79B0-7CC3-98C4-DC0C0C07398F")))
+	if err != nil || ms != rfc9562Time.UnixMilli() {
+		t.Errorf("TimestampFromV7() = %d, %v, want %d", ms, err, rfc9562Time.UnixMilli())
+	}
+	for _, tt := range rfc9562Vectors {
+		if tt.version == V7 {
+			continue
+		}
+		if ms, err := TimestampFromV7(Must(FromString(tt.uuid))); err == nil {
+			t.Errorf("%s: TimestampFromV7() of a version %d UUID = %d, want error", tt.section, tt.version, ms)
+		}
+	}
+}
+
+// testRFC9562V1V6Fields checks that the V1 and V6 vectors hold the same
+// timestamp, clock sequence and node, in their respective layouts.
+func testRFC9562V1V6Fields(t *testing.T) {
+	v1 := Must(FromString("C232AB00-9414-11EC-B3C8-9F6BDECED846"))
+	v6 := Must(FromString("1EC9414C-232A-6B00-B3C8-9F6BDECED846"))
+	for _, u := range []UUID{v1, v6} {
+		if seq := uint16(u[8]&0x3f)<<8 | uint16(u[9]); seq != 0x33C8 {
+			t.Errorf("%v: clock sequence = %#x, want 0x33c8", u, seq)
+		}
+		if node := u.Format(FormatHash)[20:]; node != "9f6bdeced846" {
+			t.Errorf("%v: node = %s, want 9f6bdeced846", u, node)
+		}
+	}
+
+	// Both timestamps count 100-nanosecond intervals since the UUID epoch.
+	ts1 := uint64(v1[6]&0x0f)<<56 | uint64(v1[7])<<48 |
+		uint64(v1[4])<<40 | uint64(v1[5])<<32 |
+		uint64(v1[0])<<24 | uint64(v1[1])<<16 | uint64(v1[2])<<8 | uint64(v1[3])
+	ts6 := uint64(v6[0])<<52 | uint64(v6[1])<<44 | uint64(v6[2])<<36 | uint64(v6[3])<<28 |
+		uint64(v6[4])<<20 | uint64(v6[5])<<12 |
+		uint64(v6[6]&0x0f)<<8 | uint64(v6[7])
+	want := epochStart + uint64(rfc9562Time.UnixNano()/100)
+	if ts1 != want || ts6 != want {
+		t.Errorf("V1 timestamp %d, V6 timestamp %d, want %d", ts1, ts6, want)
+	}
+}
+
+// testRFC9562NameBased rebuilds the name-based vectors from the hash of the
+// namespace and name.
+func testRFC9562NameBased(t *testing.T) {
+	tests := []struct {
+		section string
+		h       hash.Hash
+		version byte
+		want    string
+	}{
+		{section: "A.2", h: md5.New(), version: V3, want: "5df41881-3aed-3515-88a7-2f4a814cf09e"},
+		{section: "A.4", h: sha1.New(), version: V5, want: "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+		{section: "B.2", h: sha256.New(), version: 8, want: "5c146b14-3c52-8afd-938a-375d0df1fbf6"},
+	}
+	for _, tt := range tests {
+		tt.h.Write(NamespaceDNS[:])
+		tt.h.Write([]byte(rfc9562Name))
+		var got UUID
+		copy(got[:], tt.h.Sum(nil))
+		got.SetVersion(tt.version)
+		got.SetVariant(VariantRFC4122)
+		if want := Must(FromString(tt.want)); got != want {
+			t.Errorf("%s: hash of NamespaceDNS and %q = %v, want %v", tt.section, rfc9562Name, got, want)
+		}
+	}
+}
+
+func testRFC9562SpecialValues(t *testing.T) {
+	if got := Must(FromString("00000000-0000-0000-0000-000000000000")); got != Nil || !got.IsNil() {
+		t.Errorf("Nil UUID = %v", got)
+	}
+	if got := Must(FromString("FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF")); got != Omni {
+		t.Errorf("Max UUID = %v, want Omni", got)
+	}
+}
@@ -0,0 +1,131 @@
+package uuid
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+)
+
+var _ driver.Valuer = SecretUUID{}
+var _ sql.Scanner = (*SecretUUID)(nil)
+var _ fmt.Formatter = SecretUUID{}
+var _ fmt.GoStringer = SecretUUID{}
+
+// redacted replaces a SecretUUID, or an EncryptedV7 or SignedID that cannot
+// be encrypted or signed, in formatted output.
+const redacted = "[redacted]"
+
+var errSecretMarshal = errors.New("uuid: cannot marshal a SecretUUID; marshal Reveal() instead")
+
+// ConstantTimeEqual reports whether a and b are equal, in time that doesn't
+// depend on their contents. Use it instead of == to compare UUIDs used as
+// bearer tokens.
+func ConstantTimeEqual(a, b UUID) bool {
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// Zeroize overwrites u with zeros. The Go runtime may hold copies of u, so
+// this limits, but doesn't prevent, how long a secret stays in memory.
+func Zeroize(u *UUID) {
+	ZeroizeBytes(u[:])
+}
+
+// ZeroizeBytes overwrites b with zeros. See Zeroize.
+func ZeroizeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+	runtime.KeepAlive(b)
+}
+
+// SecretUUID is a UUID used as a secret, such as an invite link or API key.
+// String, Format, GoString and LogValue redact it, and MarshalText and
+// MarshalJSON return an error, so it doesn't leak into logs or API responses.
+// Use Reveal to send it.
+//
+// Value and Scan store the UUID as is, and UnmarshalText and UnmarshalJSON
+// parse it as a UUID.
+type SecretUUID struct {
+	u UUID
+}
+
+// NewSecretUUID returns a SecretUUID holding u.
+func NewSecretUUID(u UUID) SecretUUID {
+	return SecretUUID{u: u}
+}
+
+// Reveal returns the secret UUID.
+func (s SecretUUID) Reveal() UUID {
+	return s.u
+}
+
+// Equal reports whether s holds u, in constant time.
+func (s SecretUUID) Equal(u UUID) bool {
+	return ConstantTimeEqual(s.u, u)
+}
+
+// IsNil returns if the secret is Nil.
+func (s SecretUUID) IsNil() bool {
+	return ConstantTimeEqual(s.u, Nil)
+}
+
+// Zeroize overwrites the secret with zeros.
+func (s *SecretUUID) Zeroize() {
+	Zeroize(&s.u)
+}
+
+// String returns a redacted placeholder.
+func (s SecretUUID) String() string {
+	return redacted
+}
+
+// GoString returns a redacted placeholder.
+func (s SecretUUID) GoString() string {
+	return "uuid.SecretUUID{" + redacted + "}"
+}
+
+// Format implements the fmt.Formatter interface. All verbs print a redacted
+// placeholder.
+func (s SecretUUID) Format(f fmt.State, c rune) {
+	if c == 'v' && f.Flag('#') {
+		_, _ = io.WriteString(f, s.GoString())
+		return
+	}
+	_, _ = io.WriteString(f, redacted)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. It always
+// returns an error, so the secret isn't written by accident.
+func (s SecretUUID) MarshalText() ([]byte, error) {
+	return nil, errSecretMarshal
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *SecretUUID) UnmarshalText(b []byte) error {
+	return s.u.UnmarshalText(b)
+}
+
+// MarshalJSON implements the json.Marshaler interface. It always returns an
+// error, so the secret isn't written by accident.
+func (s SecretUUID) MarshalJSON() ([]byte, error) {
+	return nil, errSecretMarshal
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (s *SecretUUID) UnmarshalJSON(b []byte) error {
+	return s.u.UnmarshalJSON(b)
+}
+
+// Value implements the driver.Valuer interface.
+func (s SecretUUID) Value() (driver.Value, error) {
+	return s.u.Value()
+}
+
+// Scan implements the sql.Scanner interface.
+func (s *SecretUUID) Scan(src interface{}) error {
+	return s.u.Scan(src)
+}
@@ -0,0 +1,13 @@
+//go:build go1.21
+
+package uuid
+
+import "log/slog"
+
+var _ slog.LogValuer = SecretUUID{}
+
+// LogValue implements the slog.LogValuer interface. It returns a redacted
+// placeholder.
+func (s SecretUUID) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
@@ -0,0 +1,25 @@
+//go:build go1.21
+
+package uuid
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSecretUUIDLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("invite", "token", NewSecretUUID(codecTestUUID))
+	logger.Info("invite", slog.Any("token", NewSecretUUID(codecTestUUID)))
+
+	out := buf.String()
+	if strings.Count(out, redacted) != 2 {
+		t.Errorf("log output is not redacted: %s", out)
+	}
+	if strings.Contains(out, codecTestUUID.String()) {
+		t.Errorf("log output leaks the secret: %s", out)
+	}
+}
@@ -0,0 +1,156 @@
+package uuid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestConstantTimeEqual(t *testing.T) {
+	u := codecTestUUID
+	v := u
+	v[15] ^= 1
+	tests := []struct {
+		a, b UUID
+		want bool
+	}{
+		{a: u, b: u, want: true},
+		{a: Nil, b: Nil, want: true},
+		{a: u, b: v, want: false},
+		{a: Nil, b: Omni, want: false},
+	}
+	for _, tt := range tests {
+		if got := ConstantTimeEqual(tt.a, tt.b); got != tt.want {
+			t.Errorf("ConstantTimeEqual(%v, %v) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestZeroize(t *testing.T) {
+	u := Must(NewV4())
+	Zeroize(&u)
+	if u != Nil {
+		t.Errorf("Zeroize left %v", u)
+	}
+	b := []byte{1, 2, 3}
+	ZeroizeBytes(b)
+	if b[0]|b[1]|b[2] != 0 {
+		t.Errorf("ZeroizeBytes left %v", b)
+	}
+}
+
+func TestSecretUUID(t *testing.T) {
+	t.Run("Redacted", testSecretUUIDRedacted)
+	t.Run("Reveal", testSecretUUIDReveal)
+	t.Run("Zeroize", testSecretUUIDZeroize)
+	t.Run("Unmarshal", testSecretUUIDUnmarshal)
+	t.Run("Marshal", testSecretUUIDMarshal)
+	t.Run("SQL", testSecretUUIDSQL)
+}
+
+func testSecretUUIDRedacted(t *testing.T) {
+	s := NewSecretUUID(codecTestUUID)
+	secret := codecTestUUID.String()
+	hash := codecTestUUID.Format(FormatHash)
+	b58 := codecTestUUID.Format(FormatBase58)
+
+	type wrapper struct {
+		Token SecretUUID
+		Ptr   *SecretUUID
+	}
+	outputs := []string{
+		s.String(),
+		s.GoString(),
+		fmt.Sprint(s),
+		fmt.Sprintf("%v %+v %#v %s %q %x %X %d", s, s, s, s, s, s, s, s),
+		fmt.Sprintf("%v %+v %#v", wrapper{s, &s}, wrapper{s, &s}, wrapper{s, &s}),
+	}
+	for _, out := range outputs {
+		if !strings.Contains(out, redacted) {
+			t.Errorf("output %q is not redacted", out)
+		}
+		for _, leak := range []string{secret, hash, b58, "6ba7", "6BA7"} {
+			if strings.Contains(out, leak) {
+				t.Errorf("output %q leaks %s", out, leak)
+			}
+		}
+	}
+}
+
+func testSecretUUIDReveal(t *testing.T) {
+	s := NewSecretUUID(codecTestUUID)
+	if s.Reveal() != codecTestUUID {
+		t.Errorf("Reveal() = %v, want %v", s.Reveal(), codecTestUUID)
+	}
+	if !s.Equal(codecTestUUID) || s.Equal(Nil) {
+		t.Error("Equal() is wrong")
+	}
+	if s.IsNil() || !(SecretUUID{}).IsNil() {
+		t.Error("IsNil() is wrong")
+	}
+}
+
+func testSecretUUIDZeroize(t *testing.T) {
+	s := NewSecretUUID(Must(NewV4()))
+	s.Zeroize()
+	if !s.IsNil() {
+		t.Errorf("Zeroize left %v", s.Reveal())
+	}
+}
+
+func testSecretUUIDUnmarshal(t *testing.T) {
+	var s SecretUUID
+	if err := s.UnmarshalText([]byte(codecTestUUID.String())); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Equal(codecTestUUID) {
+		t.Errorf("UnmarshalText() = %v, want %v", s.Reveal(), codecTestUUID)
+	}
+	var w struct{ Token SecretUUID }
+	if err := json.Unmarshal([]byte(`{"Token":"`+codecTestUUID.String()+`"}`), &w); err != nil {
+		t.Fatal(err)
+	}
+	if !w.Token.Equal(codecTestUUID) {
+		t.Errorf("json.Unmarshal = %v, want %v", w.Token.Reveal(), codecTestUUID)
+	}
+}
+
+func testSecretUUIDMarshal(t *testing.T) {
+	s := NewSecretUUID(codecTestUUID)
+	if b, err := s.MarshalText(); err != errSecretMarshal || b != nil {
+		t.Errorf("MarshalText() = %q, %v, want %v", b, err, errSecretMarshal)
+	}
+	if b, err := s.MarshalJSON(); err != errSecretMarshal || b != nil {
+		t.Errorf("MarshalJSON() = %q, %v, want %v", b, err, errSecretMarshal)
+	}
+	type invite struct {
+		Token SecretUUID
+	}
+	if b, err := json.Marshal(invite{Token: s}); !errors.Is(err, errSecretMarshal) {
+		t.Errorf("json.Marshal = %s, %v, want %v", b, err, errSecretMarshal)
+	}
+}
+
+func testSecretUUIDSQL(t *testing.T) {
+	s := NewSecretUUID(codecTestUUID)
+	v, err := s.Value()
+	if err != nil || v != codecTestUUID.String() {
+		t.Errorf("Value() = %v, %v, want %v", v, err, codecTestUUID)
+	}
+	var got SecretUUID
+	if err := got.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(codecTestUUID) {
+		t.Errorf("Scan(%v) = %v", v, got.Reveal())
+	}
+}
+
+func BenchmarkConstantTimeEqual(b *testing.B) {
+	u, v := Must(NewV4()), Must(NewV4())
+	for i := 0; i < b.N; i++ {
+		ConstantTimeEqual(u, v)
+	}
+}
@@ -0,0 +1,370 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+)
+
+var _ driver.Valuer = (*Set)(nil)
+var _ sql.Scanner = (*Set)(nil)
+var _ driver.Valuer = (*SortedSet)(nil)
+var _ sql.Scanner = (*SortedSet)(nil)
+
+// Set is an unordered set of UUIDs. The zero value is an empty set ready to
+// use, and a nil *Set reads as an empty set. Iteration is in sorted order,
+// so output is deterministic.
+//
+// A Set is marshaled to JSON as an array and stored in SQL as a PostgreSQL
+// uuid[] array.
+type Set struct {
+	m map[UUID]struct{}
+}
+
+// NewSet returns a Set containing ids.
+func NewSet(ids ...UUID) *Set {
+	s := &Set{m: make(map[UUID]struct{}, len(ids))}
+	s.Add(ids...)
+	return s
+}
+
+// items returns the UUIDs in s, or nil if s is nil.
+func (s *Set) items() map[UUID]struct{} {
+	if s == nil {
+		return nil
+	}
+	return s.m
+}
+
+// Add adds ids to the set.
+func (s *Set) Add(ids ...UUID) {
+	if s.m == nil {
+		s.m = make(map[UUID]struct{}, len(ids))
+	}
+	for _, u := range ids {
+		s.m[u] = struct{}{}
+	}
+}
+
+// Remove removes ids from the set.
+func (s *Set) Remove(ids ...UUID) {
+	for _, u := range ids {
+		delete(s.m, u)
+	}
+}
+
+// Contains reports whether u is in the set.
+func (s *Set) Contains(u UUID) bool {
+	_, ok := s.items()[u]
+	return ok
+}
+
+// Len returns the number of UUIDs in the set.
+func (s *Set) Len() int {
+	return len(s.items())
+}
+
+// Union returns a new set with the UUIDs that are in s or o. A nil set is
+// empty.
+func (s *Set) Union(o *Set) *Set {
+	sm, om := s.items(), o.items()
+	r := &Set{m: make(map[UUID]struct{}, len(sm)+len(om))}
+	for u := range sm {
+		r.m[u] = struct{}{}
+	}
+	for u := range om {
+		r.m[u] = struct{}{}
+	}
+	return r
+}
+
+// Intersection returns a new set with the UUIDs that are in both s and o. A
+// nil set is empty.
+func (s *Set) Intersection(o *Set) *Set {
+	sm, om := s.items(), o.items()
+	if len(om) < len(sm) {
+		sm, om = om, sm
+	}
+	r := &Set{m: make(map[UUID]struct{})}
+	for u := range sm {
+		if _, ok := om[u]; ok {
+			r.m[u] = struct{}{}
+		}
+	}
+	return r
+}
+
+// Difference returns a new set with the UUIDs that are in s but not in o. A
+// nil set is empty.
+func (s *Set) Difference(o *Set) *Set {
+	om := o.items()
+	r := &Set{m: make(map[UUID]struct{})}
+	for u := range s.items() {
+		if _, ok := om[u]; !ok {
+			r.m[u] = struct{}{}
+		}
+	}
+	return r
+}
+
+// Slice returns the UUIDs in the set in sorted order.
+func (s *Set) Slice() []UUID {
+	m := s.items()
+	ids := make([]UUID, 0, len(m))
+	for u := range m {
+		ids = append(ids, u)
+	}
+	Sort(ids)
+	return ids
+}
+
+// Range calls fn for each UUID in the set in sorted order, until fn returns
+// false.
+func (s *Set) Range(fn func(u UUID) bool) {
+	for _, u := range s.Slice() {
+		if !fn(u) {
+			return
+		}
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface. The set is marshaled
+// as a sorted array.
+func (s *Set) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Slice())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. JSON null is an
+// empty set.
+func (s *Set) UnmarshalJSON(b []byte) error {
+	var ids []UUID
+	if err := json.Unmarshal(b, &ids); err != nil {
+		return err
+	}
+	*s = *NewSet(ids...)
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The set is stored as a
+// sorted PostgreSQL array.
+func (s *Set) Value() (driver.Value, error) {
+	return UUIDs(s.Slice()).Value()
+}
+
+// Scan implements the sql.Scanner interface. NULL is an empty set.
+func (s *Set) Scan(src interface{}) error {
+	var ids UUIDs
+	if err := ids.Scan(src); err != nil {
+		return err
+	}
+	*s = *NewSet(ids...)
+	return nil
+}
+
+// SortedSet is a set of UUIDs backed by a sorted slice. Iterating a set of
+// v7 UUIDs is in creation order. The zero value is an empty set ready to
+// use, and a nil *SortedSet reads as an empty set.
+//
+// Lookups are O(log n) and inserting or removing is O(n), so SortedSet suits
+// sets that are read more often than they are changed.
+type SortedSet struct {
+	ids []UUID
+}
+
+// NewSortedSet returns a SortedSet containing ids.
+func NewSortedSet(ids ...UUID) *SortedSet {
+	s := &SortedSet{ids: make([]UUID, len(ids))}
+	copy(s.ids, ids)
+	Sort(s.ids)
+	s.ids = compact(s.ids)
+	return s
+}
+
+// compact removes consecutive duplicates from the sorted slice s.
+func compact(s []UUID) []UUID {
+	if len(s) < 2 {
+		return s
+	}
+	n := 1
+	for i := 1; i < len(s); i++ {
+		if s[i] != s[n-1] {
+			s[n] = s[i]
+			n++
+		}
+	}
+	return s[:n]
+}
+
+// items returns the UUIDs in s, or nil if s is nil.
+func (s *SortedSet) items() []UUID {
+	if s == nil {
+		return nil
+	}
+	return s.ids
+}
+
+// Add adds ids to the set.
+func (s *SortedSet) Add(ids ...UUID) {
+	for _, u := range ids {
+		i, ok := BinarySearch(s.ids, u)
+		if ok {
+			continue
+		}
+		s.ids = append(s.ids, Nil)
+		copy(s.ids[i+1:], s.ids[i:])
+		s.ids[i] = u
+	}
+}
+
+// Remove removes ids from the set.
+func (s *SortedSet) Remove(ids ...UUID) {
+	for _, u := range ids {
+		if i, ok := BinarySearch(s.ids, u); ok {
+			s.ids = append(s.ids[:i], s.ids[i+1:]...)
+		}
+	}
+}
+
+// Contains reports whether u is in the set.
+func (s *SortedSet) Contains(u UUID) bool {
+	return ContainsSorted(s.items(), u)
+}
+
+// Len returns the number of UUIDs in the set.
+func (s *SortedSet) Len() int {
+	return len(s.items())
+}
+
+// At returns the i-th smallest UUID in the set.
+func (s *SortedSet) At(i int) UUID {
+	return s.items()[i]
+}
+
+// Union returns a new set with the UUIDs that are in s or o. A nil set is
+// empty.
+func (s *SortedSet) Union(o *SortedSet) *SortedSet {
+	a, b := s.items(), o.items()
+	r := make([]UUID, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := Compare(a[i], b[j]); {
+		case c < 0:
+			r = append(r, a[i])
+			i++
+		case c > 0:
+			r = append(r, b[j])
+			j++
+		default:
+			r = append(r, a[i])
+			i++
+			j++
+		}
+	}
+	r = append(r, a[i:]...)
+	r = append(r, b[j:]...)
+	return &SortedSet{ids: r}
+}
+
+// Intersection returns a new set with the UUIDs that are in both s and o. A
+// nil set is empty.
+func (s *SortedSet) Intersection(o *SortedSet) *SortedSet {
+	a, b := s.items(), o.items()
+	var r []UUID
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := Compare(a[i], b[j]); {
+		case c < 0:
+			i++
+		case c > 0:
+			j++
+		default:
+			r = append(r, a[i])
+			i++
+			j++
+		}
+	}
+	return &SortedSet{ids: r}
+}
+
+// Difference returns a new set with the UUIDs that are in s but not in o. A
+// nil set is empty.
+func (s *SortedSet) Difference(o *SortedSet) *SortedSet {
+	a, b := s.items(), o.items()
+	var r []UUID
+	i, j := 0, 0
+	for i < len(a) {
+		if j == len(b) {
+			r = append(r, a[i:]...)
+			break
+		}
+		switch c := Compare(a[i], b[j]); {
+		case c < 0:
+			r = append(r, a[i])
+			i++
+		case c > 0:
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return &SortedSet{ids: r}
+}
+
+// Slice returns a copy of the UUIDs in the set in sorted order.
+func (s *SortedSet) Slice() []UUID {
+	ids := make([]UUID, s.Len())
+	copy(ids, s.items())
+	return ids
+}
+
+// Range calls fn for each UUID in the set in sorted order, until fn returns
+// false. The set must not be modified by fn.
+func (s *SortedSet) Range(fn func(u UUID) bool) {
+	for _, u := range s.items() {
+		if !fn(u) {
+			return
+		}
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface. The set is marshaled
+// as a sorted array.
+func (s *SortedSet) MarshalJSON() ([]byte, error) {
+	ids := s.items()
+	if ids == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(ids)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. JSON null is an
+// empty set.
+func (s *SortedSet) UnmarshalJSON(b []byte) error {
+	var ids []UUID
+	if err := json.Unmarshal(b, &ids); err != nil {
+		return err
+	}
+	*s = *NewSortedSet(ids...)
+	return nil
+}
+
+// Value implements the driver.Valuer interface. The set is stored as a
+// sorted PostgreSQL array.
+func (s *SortedSet) Value() (driver.Value, error) {
+	ids := s.items()
+	if ids == nil {
+		return UUIDs{}.Value()
+	}
+	return UUIDs(ids).Value()
+}
+
+// Scan implements the sql.Scanner interface. NULL is an empty set.
+func (s *SortedSet) Scan(src interface{}) error {
+	var ids UUIDs
+	if err := ids.Scan(src); err != nil {
+		return err
+	}
+	*s = *NewSortedSet(ids...)
+	return nil
+}
@@ -0,0 +1,273 @@
+package uuid
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var (
+	setTestA = Must(FromString("00000000-0000-0000-0000-000000000001"))
+	setTestB = Must(FromString("00000000-0000-0000-0000-000000000002"))
+	setTestC = Must(FromString("00000000-0000-0000-0000-000000000003"))
+)
+
+func TestSet(t *testing.T) {
+	t.Run("ZeroValue", testSetZeroValue)
+	t.Run("AddRemove", testSetAddRemove)
+	t.Run("Operations", testSetOperations)
+	t.Run("Range", testSetRange)
+	t.Run("JSON", testSetJSON)
+	t.Run("SQL", testSetSQL)
+}
+
+func testSetZeroValue(t *testing.T) {
+	var s Set
+	if s.Len() != 0 || s.Contains(Nil) {
+		t.Fatalf("zero Set is not empty")
+	}
+	s.Remove(setTestA)
+	s.Add(setTestA)
+	if !s.Contains(setTestA) {
+		t.Errorf("Contains(%v) = false after Add", setTestA)
+	}
+
+	var n *Set
+	if n.Len() != 0 || n.Contains(setTestA) || n.Union(&s).Len() != 1 {
+		t.Errorf("nil *Set is not an empty set")
+	}
+}
+
+func testSetAddRemove(t *testing.T) {
+	s := NewSet(setTestC, setTestA, setTestA)
+	if s.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", s.Len())
+	}
+	s.Add(setTestB)
+	s.Remove(setTestA, setTestC)
+	if got, want := s.Slice(), []UUID{setTestB}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Slice() = %v, want %v", got, want)
+	}
+}
+
+func testSetOperations(t *testing.T) {
+	ab := NewSet(setTestA, setTestB)
+	bc := NewSet(setTestB, setTestC)
+	tests := []struct {
+		name string
+		got  *Set
+		want []UUID
+	}{
+		{name: "Union", got: ab.Union(bc), want: []UUID{setTestA, setTestB, setTestC}},
+		{name: "Intersection", got: ab.Intersection(bc), want: []UUID{setTestB}},
+		{name: "Difference", got: ab.Difference(bc), want: []UUID{setTestA}},
+		{name: "EmptyIntersection", got: ab.Intersection(&Set{}), want: []UUID{}},
+		{name: "UnionNil", got: ab.Union(nil), want: []UUID{setTestA, setTestB}},
+		{name: "IntersectionNil", got: ab.Intersection(nil), want: []UUID{}},
+		{name: "DifferenceNil", got: ab.Difference(nil), want: []UUID{setTestA, setTestB}},
+	}
+	for _, tt := range tests {
+		if got := tt.got.Slice(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if ab.Len() != 2 || bc.Len() != 2 {
+		t.Errorf("operations modified their operands")
+	}
+}
+
+func testSetRange(t *testing.T) {
+	s := NewSet(setTestC, setTestB, setTestA)
+	var got []UUID
+	s.Range(func(u UUID) bool {
+		got = append(got, u)
+		return len(got) < 2
+	})
+	if want := []UUID{setTestA, setTestB}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Range visited %v, want %v", got, want)
+	}
+}
+
+func testSetJSON(t *testing.T) {
+	b, err := json.Marshal(NewSet(setTestB, setTestA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `["00000000-0000-0000-0000-000000000001","00000000-0000-0000-0000-000000000002"]`
+	if string(b) != want {
+		t.Errorf("json.Marshal = %s, want %s", b, want)
+	}
+	b, err = json.Marshal(&Set{})
+	if err != nil || string(b) != "[]" {
+		t.Errorf("json.Marshal(&Set{}) = %s, %v, want []", b, err)
+	}
+
+	var s Set
+	if err := json.Unmarshal([]byte(want), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 || !s.Contains(setTestA) || !s.Contains(setTestB) {
+		t.Errorf("json.Unmarshal = %v", s.Slice())
+	}
+	if err := json.Unmarshal([]byte("null"), &s); err != nil || s.Len() != 0 {
+		t.Errorf("json.Unmarshal(null) = %v, %v, want empty set", s.Slice(), err)
+	}
+	if err := json.Unmarshal([]byte(`["bad"]`), &s); err == nil {
+		t.Errorf("json.Unmarshal of an invalid UUID did not fail")
+	}
+}
+
+func testSetSQL(t *testing.T) {
+	v, err := NewSet(setTestB, setTestA).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{00000000-0000-0000-0000-000000000001,00000000-0000-0000-0000-000000000002}"
+	if v != want {
+		t.Errorf("Value() = %v, want %s", v, want)
+	}
+	if v, err := (&Set{}).Value(); err != nil || v != "{}" {
+		t.Errorf("Set{}.Value() = %v, %v, want {}", v, err)
+	}
+
+	var s Set
+	if err := s.Scan(want); err != nil {
+		t.Fatal(err)
+	}
+	if s.Len() != 2 || !s.Contains(setTestA) || !s.Contains(setTestB) {
+		t.Errorf("Scan = %v", s.Slice())
+	}
+	if err := s.Scan(nil); err != nil || s.Len() != 0 {
+		t.Errorf("Scan(nil) = %v, %v, want empty set", s.Slice(), err)
+	}
+	if err := s.Scan(42); err == nil {
+		t.Errorf("Scan(42) did not fail")
+	}
+}
+
+func TestSortedSet(t *testing.T) {
+	t.Run("AddRemove", testSortedSetAddRemove)
+	t.Run("Operations", testSortedSetOperations)
+	t.Run("CreationOrder", testSortedSetCreationOrder)
+	t.Run("JSON", testSortedSetJSON)
+	t.Run("SQL", testSortedSetSQL)
+}
+
+func testSortedSetAddRemove(t *testing.T) {
+	var s SortedSet
+	s.Add(setTestC, setTestA, setTestC, setTestB)
+	if got, want := s.Slice(), []UUID{setTestA, setTestB, setTestC}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Slice() = %v, want %v", got, want)
+	}
+	if s.At(0) != setTestA || !s.Contains(setTestB) {
+		t.Errorf("At(0) = %v, Contains(%v) = %t", s.At(0), setTestB, s.Contains(setTestB))
+	}
+	s.Remove(setTestB, Omni)
+	if got, want := s.Slice(), []UUID{setTestA, setTestC}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Slice() = %v, want %v", got, want)
+	}
+	if n := NewSortedSet(setTestB, setTestA, setTestB).Len(); n != 2 {
+		t.Errorf("NewSortedSet with duplicates has Len() = %d, want 2", n)
+	}
+}
+
+func testSortedSetOperations(t *testing.T) {
+	ab := NewSortedSet(setTestA, setTestB)
+	bc := NewSortedSet(setTestB, setTestC)
+	tests := []struct {
+		name string
+		got  *SortedSet
+		want []UUID
+	}{
+		{name: "Union", got: ab.Union(bc), want: []UUID{setTestA, setTestB, setTestC}},
+		{name: "Intersection", got: ab.Intersection(bc), want: []UUID{setTestB}},
+		{name: "Difference", got: ab.Difference(bc), want: []UUID{setTestA}},
+		{name: "DifferenceEmpty", got: ab.Difference(&SortedSet{}), want: []UUID{setTestA, setTestB}},
+		{name: "EmptyIntersection", got: ab.Intersection(&SortedSet{}), want: []UUID{}},
+		{name: "UnionNil", got: ab.Union(nil), want: []UUID{setTestA, setTestB}},
+		{name: "IntersectionNil", got: ab.Intersection(nil), want: []UUID{}},
+		{name: "DifferenceNil", got: ab.Difference(nil), want: []UUID{setTestA, setTestB}},
+	}
+	for _, tt := range tests {
+		if got := tt.got.Slice(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func testSortedSetCreationOrder(t *testing.T) {
+	g := newSteppingTestGen()
+	ids := make([]UUID, 100)
+	for i := range ids {
+		ids[i] = Must(g.NewV7())
+	}
+	var s SortedSet
+	for i := len(ids) - 1; i >= 0; i-- {
+		s.Add(ids[i])
+	}
+	var got []UUID
+	s.Range(func(u UUID) bool {
+		got = append(got, u)
+		return true
+	})
+	if !reflect.DeepEqual(got, ids) {
+		t.Errorf("Range is not in creation order")
+	}
+}
+
+func testSortedSetJSON(t *testing.T) {
+	b, err := json.Marshal(NewSortedSet(setTestB, setTestA))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `["00000000-0000-0000-0000-000000000001","00000000-0000-0000-0000-000000000002"]`
+	if string(b) != want {
+		t.Errorf("json.Marshal = %s, want %s", b, want)
+	}
+	b, err = json.Marshal(&SortedSet{})
+	if err != nil || string(b) != "[]" {
+		t.Errorf("json.Marshal(&SortedSet{}) = %s, %v, want []", b, err)
+	}
+
+	var s SortedSet
+	if err := json.Unmarshal([]byte(`["00000000-0000-0000-0000-000000000002","00000000-0000-0000-0000-000000000001"]`), &s); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Slice(), []UUID{setTestA, setTestB}; !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal = %v, want %v", got, want)
+	}
+}
+
+func testSortedSetSQL(t *testing.T) {
+	v, err := NewSortedSet(setTestB, setTestA).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{00000000-0000-0000-0000-000000000001,00000000-0000-0000-0000-000000000002}"
+	if v != want {
+		t.Errorf("Value() = %v, want %s", v, want)
+	}
+	if v, err := (&SortedSet{}).Value(); err != nil || v != "{}" {
+		t.Errorf("SortedSet{}.Value() = %v, %v, want {}", v, err)
+	}
+
+	var s SortedSet
+	if err := s.Scan([]byte(want)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Slice(), []UUID{setTestA, setTestB}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Scan = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkSortedSetContains(b *testing.B) {
+	ids := make([]UUID, 10000)
+	for i := range ids {
+		ids[i] = randomTestUUID()
+	}
+	s := NewSortedSet(ids...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Contains(ids[i%len(ids)])
+	}
+}
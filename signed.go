@@ -0,0 +1,191 @@
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// signatureSize is the size of the truncated HMAC-SHA256 in a token.
+	signatureSize = 8
+
+	// signedSize is the size of a decoded token: key ID, UUID and signature.
+	signedSize = 1 + Size + signatureSize
+
+	// minSigningKeySize is the minimum size of a signing key secret.
+	minSigningKeySize = 16
+)
+
+// signedEncoding is lower-case Crockford base32. A 25-byte token encodes to
+// exactly 40 characters.
+var signedEncoding = base32.NewEncoding("0123456789abcdefghjkmnpqrstvwxyz").WithPadding(base32.NoPadding)
+
+var signedEncodedSize = signedEncoding.EncodedLen(signedSize)
+
+var (
+	errNoSigner         = errors.New("uuid: DefaultSigner is not set")
+	errInvalidSignature = errors.New("uuid: invalid signature")
+	errSignedIDNull     = errors.New("uuid: cannot unmarshal null into a SignedID")
+)
+
+// DefaultSigner is used by SignedID to sign and verify UUIDs.
+var DefaultSigner *Signer
+
+// SigningKey is a secret used to sign UUIDs. The ID is stored in every token
+// so tokens signed with a retired key can still be verified.
+type SigningKey struct {
+	ID     byte
+	Secret []byte
+}
+
+// Signer signs UUIDs with an HMAC-SHA256 so they can't be guessed or
+// tampered with, for example in share links.
+//
+// A token is the key ID, the UUID and the HMAC truncated to 64 bits, encoded
+// as 40 characters of lower-case Crockford base32.
+type Signer struct {
+	current SigningKey
+	keys    map[byte][]byte
+}
+
+// NewSigner returns a Signer that signs with current and verifies tokens
+// signed with current or any of old. Secrets must be at least 16 bytes and
+// key IDs must be unique.
+func NewSigner(current SigningKey, old ...SigningKey) (*Signer, error) {
+	s := &Signer{current: current, keys: make(map[byte][]byte, 1+len(old))}
+	for _, k := range append([]SigningKey{current}, old...) {
+		if len(k.Secret) < minSigningKeySize {
+			return nil, fmt.Errorf("uuid: signing key %d is shorter than %d bytes", k.ID, minSigningKeySize)
+		}
+		if _, ok := s.keys[k.ID]; ok {
+			return nil, fmt.Errorf("uuid: duplicate signing key ID %d", k.ID)
+		}
+		s.keys[k.ID] = append([]byte(nil), k.Secret...)
+	}
+	s.current.Secret = s.keys[current.ID]
+	return s, nil
+}
+
+// Sign returns the token for u, signed with the current key.
+func (s *Signer) Sign(u UUID) string {
+	var b [signedSize]byte
+	b[0] = s.current.ID
+	copy(b[1:], u[:])
+	copy(b[1+Size:], mac(s.current.Secret, b[:1+Size]))
+	return signedEncoding.EncodeToString(b[:])
+}
+
+// Verify returns the UUID in token if it was signed with one of the keys of
+// s. The signature is compared in constant time.
+func (s *Signer) Verify(token string) (UUID, error) {
+	if len(token) != signedEncodedSize {
+		return Nil, fmt.Errorf("uuid: incorrect signed UUID length %d", len(token))
+	}
+	var b [signedSize]byte
+	if _, err := signedEncoding.Decode(b[:], []byte(token)); err != nil {
+		return Nil, errInvalidSignature
+	}
+	secret, ok := s.keys[b[0]]
+	if !ok {
+		return Nil, errInvalidSignature
+	}
+	if !hmac.Equal(b[1+Size:], mac(secret, b[:1+Size])) {
+		return Nil, errInvalidSignature
+	}
+	var u UUID
+	copy(u[:], b[1:1+Size])
+	return u, nil
+}
+
+// mac returns the truncated HMAC-SHA256 of msg.
+func mac(secret, msg []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(msg)
+	return h.Sum(nil)[:signatureSize]
+}
+
+// SignedID is a UUID that is signed with DefaultSigner when marshaled to
+// text or JSON and verified when unmarshaled, so forged or tampered IDs are
+// rejected while decoding a request. Value and Scan store the UUID unsigned.
+type SignedID struct {
+	UUID
+}
+
+// Token returns the signed token for u.
+func (u SignedID) Token() (string, error) {
+	if DefaultSigner == nil {
+		return "", errNoSigner
+	}
+	return DefaultSigner.Sign(u.UUID), nil
+}
+
+// String returns the signed token, as MarshalText does. It returns a
+// redacted placeholder if DefaultSigner is not set.
+func (u SignedID) String() string {
+	t, err := u.Token()
+	if err != nil {
+		return redacted
+	}
+	return t
+}
+
+// Format returns the signed token. Tokens have a single format, so format
+// is ignored.
+func (u SignedID) Format(format ...Format) string {
+	return u.String()
+}
+
+// GoString returns the signed token for the %#v verb.
+func (u SignedID) GoString() string {
+	return "uuid.SignedID{" + u.String() + "}"
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u SignedID) MarshalText() ([]byte, error) {
+	t, err := u.Token()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(t), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. On
+// error, u is unchanged.
+func (u *SignedID) UnmarshalText(b []byte) error {
+	if DefaultSigner == nil {
+		return errNoSigner
+	}
+	uu, err := DefaultSigner.Verify(string(b))
+	if err != nil {
+		return err
+	}
+	u.UUID = uu
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u SignedID) MarshalJSON() ([]byte, error) {
+	t, err := u.Token()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(t)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. JSON null is
+// rejected, because it carries no signature; use a *SignedID for optional
+// fields.
+func (u *SignedID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return errSignedIDNull
+	}
+	var t string
+	if err := json.Unmarshal(b, &t); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(t))
+}
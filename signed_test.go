@@ -0,0 +1,251 @@
+package uuid
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var (
+	signedTestKey1 = SigningKey{ID: 1, Secret: []byte("0123456789abcdef0123456789abcdef")}
+	signedTestKey2 = SigningKey{ID: 2, Secret: []byte("fedcba9876543210fedcba9876543210")}
+)
+
+func newTestSigner(t testing.TB, current SigningKey, old ...SigningKey) *Signer {
+	s, err := NewSigner(current, old...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSigner(t *testing.T) {
+	t.Run("RoundTrip", testSignerRoundTrip)
+	t.Run("Vector", testSignerVector)
+	t.Run("Tampered", testSignerTampered)
+	t.Run("Rotation", testSignerRotation)
+	t.Run("InvalidKeys", testSignerInvalidKeys)
+	t.Run("InvalidTokens", testSignerInvalidTokens)
+}
+
+func testSignerRoundTrip(t *testing.T) {
+	s := newTestSigner(t, signedTestKey1)
+	for i := 0; i < 100; i++ {
+		u := Must(NewV4())
+		token := s.Sign(u)
+		if len(token) != 40 {
+			t.Fatalf("Sign(%v) = %q, want 40 characters", u, token)
+		}
+		got, err := s.Verify(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != u {
+			t.Fatalf("Verify(Sign(%v)) = %v", u, got)
+		}
+	}
+}
+
+func testSignerVector(t *testing.T) {
+	s := newTestSigner(t, signedTestKey1)
+	want := "05ntfe0gkpph3mc0pg0c0kym6349sc6gbsmkhd94"
+	if got := s.Sign(codecTestUUID); got != want {
+		t.Errorf("Sign(%v) = %q, want %q", codecTestUUID, got, want)
+	}
+}
+
+func testSignerTampered(t *testing.T) {
+	s := newTestSigner(t, signedTestKey1)
+	token := s.Sign(codecTestUUID)
+	for i := range token {
+		b := []byte(token)
+		if b[i] == '0' {
+			b[i] = '1'
+		} else {
+			b[i] = '0'
+		}
+		if u, err := s.Verify(string(b)); err == nil {
+			t.Errorf("Verify(%q) = %v, want error", b, u)
+		}
+	}
+	forged := newTestSigner(t, SigningKey{ID: 1, Secret: signedTestKey2.Secret}).Sign(codecTestUUID)
+	if _, err := s.Verify(forged); err != errInvalidSignature {
+		t.Errorf("Verify of a token signed with another secret: error = %v, want %v", err, errInvalidSignature)
+	}
+}
+
+func testSignerRotation(t *testing.T) {
+	old := newTestSigner(t, signedTestKey1)
+	rotated := newTestSigner(t, signedTestKey2, signedTestKey1)
+	u := Must(NewV7())
+
+	if got, err := rotated.Verify(old.Sign(u)); err != nil || got != u {
+		t.Errorf("Verify of a token signed with an old key = %v, %v, want %v", got, err, u)
+	}
+	token := rotated.Sign(u)
+	if token == old.Sign(u) {
+		t.Error("Sign after rotation still uses the old key")
+	}
+	if _, err := old.Verify(token); err != errInvalidSignature {
+		t.Errorf("Verify with an unknown key ID: error = %v, want %v", err, errInvalidSignature)
+	}
+}
+
+func testSignerInvalidKeys(t *testing.T) {
+	if _, err := NewSigner(SigningKey{ID: 1, Secret: []byte("short")}); err == nil {
+		t.Error("NewSigner with a short secret did not fail")
+	}
+	if _, err := NewSigner(signedTestKey1, SigningKey{ID: 1, Secret: signedTestKey2.Secret}); err == nil {
+		t.Error("NewSigner with duplicate key IDs did not fail")
+	}
+}
+
+func testSignerInvalidTokens(t *testing.T) {
+	s := newTestSigner(t, signedTestKey1)
+	token := s.Sign(codecTestUUID)
+	tests := []string{
+		"",
+		token[1:],
+		token + "0",
+		strings.ToUpper(token),
+		"05ntfe0gkpph3mc0pg0c0kym6349sc6gbsmkhd9u",
+		codecTestUUID.String(),
+	}
+	for _, tt := range tests {
+		u, err := s.Verify(tt)
+		if err == nil {
+			t.Errorf("Verify(%q) = %v, want error", tt, u)
+		} else if tt != "" && strings.Contains(err.Error(), tt) {
+			t.Errorf("Verify(%q) error %q contains the token", tt, err)
+		}
+	}
+}
+
+func TestSignedID(t *testing.T) {
+	t.Run("Text", testSignedIDText)
+	t.Run("JSON", testSignedIDJSON)
+	t.Run("NoSigner", testSignedIDNoSigner)
+	t.Run("Value", testSignedIDValue)
+	t.Run("Formatting", testSignedIDFormatting)
+}
+
+func withTestSigner(t *testing.T, s *Signer) {
+	old := DefaultSigner
+	DefaultSigner = s
+	t.Cleanup(func() { DefaultSigner = old })
+}
+
+func testSignedIDText(t *testing.T) {
+	withTestSigner(t, newTestSigner(t, signedTestKey1))
+	u := SignedID{codecTestUUID}
+	b, err := u.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "05ntfe0gkpph3mc0pg0c0kym6349sc6gbsmkhd94"; string(b) != want {
+		t.Errorf("MarshalText() = %s, want %s", b, want)
+	}
+	var got SignedID
+	if err := got.UnmarshalText(b); err != nil {
+		t.Fatal(err)
+	}
+	if got != u {
+		t.Errorf("UnmarshalText(%s) = %v, want %v", b, got, u)
+	}
+	if err := got.UnmarshalText([]byte(Must(NewV4()).String())); err == nil {
+		t.Error("UnmarshalText of an unsigned UUID did not fail")
+	}
+	if got != u {
+		t.Error("UnmarshalText changed the UUID on error")
+	}
+}
+
+func testSignedIDJSON(t *testing.T) {
+	withTestSigner(t, newTestSigner(t, signedTestKey1))
+	type link struct {
+		ID SignedID `json:"id"`
+	}
+	in := link{ID: SignedID{Must(NewV7())}}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out link
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", b, out.ID, in.ID)
+	}
+	if err := json.Unmarshal([]byte(`{"id":null}`), &out); err != errSignedIDNull || out != in {
+		t.Errorf("json.Unmarshal(null) = %v, %v, want %v unchanged", out.ID, err, errSignedIDNull)
+	}
+	var opt struct {
+		ID *SignedID `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(`{"id":null}`), &opt); err != nil || opt.ID != nil {
+		t.Errorf("json.Unmarshal(null) into *SignedID = %v, %v, want nil", opt.ID, err)
+	}
+	if err := json.Unmarshal([]byte(`{"id":42}`), &out); err == nil {
+		t.Error("json.Unmarshal of a number did not fail")
+	}
+}
+
+func testSignedIDNoSigner(t *testing.T) {
+	withTestSigner(t, nil)
+	if _, err := (SignedID{codecTestUUID}).MarshalText(); err != errNoSigner {
+		t.Errorf("MarshalText() error = %v, want %v", err, errNoSigner)
+	}
+	var u SignedID
+	if err := u.UnmarshalText([]byte("05ntfe0gkpph3mc0pg0c0kym6349sc6gbsmkhd94")); err != errNoSigner {
+		t.Errorf("UnmarshalText() error = %v, want %v", err, errNoSigner)
+	}
+}
+
+func testSignedIDValue(t *testing.T) {
+	withTestSigner(t, newTestSigner(t, signedTestKey1))
+	v, err := SignedID{codecTestUUID}.Value()
+	if err != nil || v != codecTestUUID.String() {
+		t.Errorf("Value() = %v, %v, want the unsigned %v", v, err, codecTestUUID)
+	}
+}
+
+// testSignedIDFormatting checks that formatted output shows the token, as
+// MarshalText does, and not the bare UUID.
+func testSignedIDFormatting(t *testing.T) {
+	withTestSigner(t, newTestSigner(t, signedTestKey1))
+	u := SignedID{codecTestUUID}
+	b, err := u.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name, got, want string
+	}{
+		{"Sprint", fmt.Sprint(u), string(b)},
+		{"%s", fmt.Sprintf("%s", u), string(b)},
+		{"%v", fmt.Sprintf("%v", &u), string(b)},
+		{"%#v", fmt.Sprintf("%#v", u), "uuid.SignedID{" + string(b) + "}"},
+		{"String", u.String(), string(b)},
+		{"Format", u.Format(FormatBase58), string(b)},
+		{"Struct", fmt.Sprintf("%+v", struct{ ID SignedID }{u}), "{ID:" + string(b) + "}"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+
+	withTestSigner(t, nil)
+	if got := fmt.Sprint(u); got != redacted {
+		t.Errorf("Sprint without a signer = %s, want %s", got, redacted)
+	}
+}
+
+func BenchmarkSignerVerify(b *testing.B) {
+	s := newTestSigner(b, signedTestKey1)
+	token := s.Sign(codecTestUUID)
+	for i := 0; i < b.N; i++ {
+		s.Verify(token)
+	}
+}
@@ -28,33 +28,97 @@ import (
 
 var _ driver.Valuer = UUID{}
 var _ sql.Scanner = (*UUID)(nil)
+var _ driver.Valuer = BinaryUUID{}
+var _ sql.Scanner = (*BinaryUUID)(nil)
+
+// ValueFunc returns the driver.Value representation of a UUID.
+type ValueFunc func(u UUID) (driver.Value, error)
+
+// DefaultValueFunc is used by UUID.Value. Set it to ValueBinary for
+// BINARY(16) and BLOB columns, or to a custom ValueFunc.
+var DefaultValueFunc ValueFunc = ValueCanonical
+
+// ValueCanonical returns u as a canonical RFC-4122 string.
+func ValueCanonical(u UUID) (driver.Value, error) {
+	return u.String(), nil
+}
+
+// ValueBinary returns u as a 16-byte slice.
+func ValueBinary(u UUID) (driver.Value, error) {
+	return u.Bytes(), nil
+}
+
+// ValueHash returns u as a hash string.
+func ValueHash(u UUID) (driver.Value, error) {
+	return u.Format(FormatHash), nil
+}
+
+// ValueBase58 returns u as a base58 string.
+func ValueBase58(u UUID) (driver.Value, error) {
+	return u.Format(FormatBase58), nil
+}
 
 // Value implements the driver.Valuer interface.
+// The representation is determined by DefaultValueFunc.
 func (u UUID) Value() (driver.Value, error) {
-	return u.String(), nil
+	return DefaultValueFunc(u)
+}
+
+// BinaryUUID is a UUID that is always stored as 16 bytes, regardless of
+// DefaultValueFunc. Use it for BINARY(16) and BLOB columns when other
+// columns hold UUIDs as text.
+type BinaryUUID struct {
+	UUID
+}
+
+// Value implements the driver.Valuer interface.
+func (u BinaryUUID) Value() (driver.Value, error) {
+	return ValueBinary(u.UUID)
 }
 
 // Scan implements the sql.Scanner interface.
-// A 16-byte slice will be handled by UnmarshalBinary, while
-// a longer byte slice or a string will be handled by UnmarshalText.
+// NULL and nil pointers scan as Nil. A UUID or [16]byte is copied, a 16-byte
+// slice is handled by UnmarshalBinary, while longer byte slices and strings
+// are handled by UnmarshalText, optionally wrapped in braces.
 func (u *UUID) Scan(src interface{}) error {
-	if src == nil {
-		return u.UnmarshalBinary(Nil.Bytes())
-	}
-
 	switch src := src.(type) {
+	case nil:
+		*u = Nil
+		return nil
+
 	case UUID: // support gorm convert from UUID to NullUUID
 		*u = src
 		return nil
 
-	case []byte:
-		if len(src) == Size {
-			return u.UnmarshalBinary(src)
+	case *UUID:
+		if src == nil {
+			*u = Nil
+		} else {
+			*u = *src
 		}
-		return u.UnmarshalText(src)
+		return nil
+
+	case [Size]byte:
+		*u = src
+		return nil
+
+	case []byte:
+		return u.scanBytes(src)
+
+	case sql.RawBytes:
+		return u.scanBytes(src)
 
 	case string:
-		uu, err := FromString(src)
+		uu, err := FromString(trimBraces(src))
+		*u = uu
+		return err
+
+	case *string:
+		if src == nil {
+			*u = Nil
+			return nil
+		}
+		uu, err := FromString(trimBraces(*src))
 		*u = uu
 		return err
 	}
@@ -62,6 +126,37 @@ func (u *UUID) Scan(src interface{}) error {
 	return fmt.Errorf("uuid: cannot convert %T to UUID", src)
 }
 
+func (u *UUID) scanBytes(src []byte) error {
+	if len(src) == Size {
+		return u.UnmarshalBinary(src)
+	}
+	return u.UnmarshalText(trimBraces(src))
+}
+
+// scanBinary returns src if it is a 16-byte slice or a [16]byte, which
+// hold a UUID as stored by the driver. A UUID source is not binary: it
+// already holds the value.
+func scanBinary(src interface{}) ([]byte, bool) {
+	switch src := src.(type) {
+	case [Size]byte:
+		return src[:], true
+	case []byte:
+		return src, len(src) == Size
+	case sql.RawBytes:
+		return src, len(src) == Size
+	}
+	return nil, false
+}
+
+// trimBraces removes the braces around a UUID in the canonical format, as
+// returned by some drivers.
+func trimBraces[T string | []byte](s T) T {
+	if len(s) == 38 && s[0] == '{' && s[37] == '}' {
+		return s[1:37]
+	}
+	return s
+}
+
 // NullUUID can be used with the standard sql package to represent a
 // UUID value that can be NULL in the database.
 type NullUUID struct {
@@ -79,15 +174,75 @@ func (u NullUUID) Value() (driver.Value, error) {
 }
 
 // Scan implements the sql.Scanner interface.
+// NULL and nil pointers scan as an invalid NullUUID.
 func (u *NullUUID) Scan(src interface{}) error {
-	if src == nil {
+	switch s := src.(type) {
+	case nil:
 		u.UUID, u.Valid = Nil, false
 		return nil
+	case *string:
+		if s == nil {
+			u.UUID, u.Valid = Nil, false
+			return nil
+		}
+	case *UUID:
+		if s == nil {
+			u.UUID, u.Valid = Nil, false
+			return nil
+		}
+	case NullUUID:
+		*u = s
+		return nil
 	}
 
 	// Delegate to UUID Scan function
-	u.Valid = true
-	return u.UUID.Scan(src)
+	err := u.UUID.Scan(src)
+	u.Valid = err == nil
+	return err
+}
+
+// NullUUIDFromPtr returns a valid NullUUID holding *p, or an invalid
+// NullUUID if p is nil.
+func NullUUIDFromPtr(p *UUID) NullUUID {
+	if p == nil {
+		return NullUUID{}
+	}
+	return NullUUID{UUID: *p, Valid: true}
+}
+
+// Ptr returns a pointer to a copy of the UUID, or nil if u is not valid.
+func (u NullUUID) Ptr() *UUID {
+	if !u.Valid {
+		return nil
+	}
+	uu := u.UUID
+	return &uu
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// An invalid NullUUID is marshaled as empty text, a valid one in the format
+// specified by DefaultFormat.
+func (u NullUUID) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return []byte{}, nil
+	}
+	return u.UUID.MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// Empty text is unmarshaled as an invalid NullUUID, anything else as
+// by UUID.UnmarshalText. On error, u is unchanged.
+func (u *NullUUID) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		u.UUID, u.Valid = Nil, false
+		return nil
+	}
+	var uu UUID
+	if err := uu.UnmarshalText(b); err != nil {
+		return err
+	}
+	u.UUID, u.Valid = uu, true
+	return nil
 }
 
 var nullJSON = []byte("null")
@@ -99,38 +254,27 @@ func (u NullUUID) MarshalJSON() ([]byte, error) {
 	if !u.Valid {
 		return nullJSON, nil
 	}
-	switch DefaultFormat {
-	case FormatCanonical:
-		var buf [38]byte
-		buf[0] = '"'
-		copy(buf[1:], u.UUID.Format(FormatCanonical))
-		buf[37] = '"'
-		return buf[:], nil
-	case FormatHash:
-		var buf [34]byte
-		buf[0] = '"'
-		copy(buf[1:], u.UUID.Format(FormatHash))
-		buf[33] = '"'
-		return buf[:], nil
-	default:
-		var buf [24]byte
-		buf[0] = '"'
-		copy(buf[1:], u.UUID.Format(FormatBase58))
-		buf[23] = '"'
-		return buf[:], nil
-	}
+	return u.UUID.MarshalJSON()
 }
 
-// UnmarshalJSON unmarshals a NullUUID
+// UnmarshalJSON unmarshals a NullUUID.
+//
+//	null             -> invalid
+//	""               -> invalid if EmptyJSONAsNull, Nil if EmptyJSONAsNil,
+//	                    otherwise an error
+//	"<uuid>"         -> valid, escape sequences are allowed
+//	anything else    -> error
+//
+// On error, u is unchanged.
 func (u *NullUUID) UnmarshalJSON(b []byte) error {
-	if string(b) == "null" {
+	if string(b) == "null" || (EmptyJSONAsNull && string(b) == `""`) {
 		u.UUID, u.Valid = Nil, false
 		return nil
 	}
-	if n := len(b); n >= 2 && b[0] == '"' {
-		b = b[1 : n-1]
+	var uu UUID
+	if err := uu.UnmarshalJSON(b); err != nil {
+		return err
 	}
-	err := u.UUID.UnmarshalText(b)
-	u.Valid = (err == nil)
-	return err
+	u.UUID, u.Valid = uu, true
+	return nil
 }
@@ -0,0 +1,53 @@
+//go:build go1.24
+
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+// sql.Null[T] only converts the result of a nested driver.Valuer since
+// Go 1.24.
+func TestSQLNullGeneric(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		in := sql.Null[UUID]{V: codecTestUUID, Valid: true}
+		v, err := driver.DefaultParameterConverter.ConvertValue(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out sql.Null[UUID]
+		if err := out.Scan(v); err != nil {
+			t.Fatalf("Scan(%v): %v", v, err)
+		}
+		if out != in {
+			t.Errorf("Scan(%v) = %+v, want %+v", v, out, in)
+		}
+	})
+	t.Run("Null", func(t *testing.T) {
+		v, err := driver.DefaultParameterConverter.ConvertValue(sql.Null[UUID]{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != nil {
+			t.Fatalf("Value() = %v, want nil", v)
+		}
+		out := sql.Null[UUID]{V: codecTestUUID, Valid: true}
+		if err := out.Scan(v); err != nil {
+			t.Fatal(err)
+		}
+		if out.Valid {
+			t.Errorf("Scan(nil) = %+v, want invalid", out)
+		}
+	})
+	t.Run("Binary", func(t *testing.T) {
+		var out sql.Null[UUID]
+		if err := out.Scan(codecTestData); err != nil {
+			t.Fatal(err)
+		}
+		if !out.Valid || out.V != codecTestUUID {
+			t.Errorf("Scan(%x) = %+v, want %v", codecTestData, out, codecTestUUID)
+		}
+	})
+}
@@ -22,19 +22,27 @@
 package uuid
 
 import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSQL(t *testing.T) {
 	t.Run("Value", testSQLValue)
+	t.Run("ValueFunc", testSQLValueFunc)
+	t.Run("BinaryUUID", testSQLBinaryUUID)
 	t.Run("Scan", func(t *testing.T) {
 		t.Run("Binary", testSQLScanBinary)
 		t.Run("String", testSQLScanString)
 		t.Run("Text", testSQLScanText)
 		t.Run("Unsupported", testSQLScanUnsupported)
 		t.Run("Nil", testSQLScanNil)
+		t.Run("Sources", testSQLScanSources)
 	})
 }
 
@@ -52,6 +60,62 @@ func testSQLValue(t *testing.T) {
 	}
 }
 
+func testSQLValueFunc(t *testing.T) {
+	defer func() { DefaultValueFunc = ValueCanonical }()
+	tests := []struct {
+		name string
+		fn   ValueFunc
+		want driver.Value
+	}{
+		{name: "Canonical", fn: ValueCanonical, want: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{name: "Binary", fn: ValueBinary, want: codecTestData},
+		{name: "Hash", fn: ValueHash, want: "6ba7b8109dad11d180b400c04fd430c8"},
+		{name: "Base58", fn: ValueBase58, want: "EJ34kCVxxF9jHMKD4EgrAK"},
+		{name: "Custom", fn: func(u UUID) (driver.Value, error) { return "custom", nil }, want: "custom"},
+	}
+	for _, tt := range tests {
+		DefaultValueFunc = tt.fn
+		v, err := codecTestUUID.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, tt.want) {
+			t.Errorf("%s: Value() = %#v, want %#v", tt.name, v, tt.want)
+		}
+		if !driver.IsValue(v) {
+			t.Errorf("%s: Value() returned invalid driver.Value %T", tt.name, v)
+		}
+		if tt.name == "Custom" {
+			continue
+		}
+		var got UUID
+		if err := got.Scan(v); err != nil {
+			t.Fatalf("%s: Scan(%v): %v", tt.name, v, err)
+		}
+		if got != codecTestUUID {
+			t.Errorf("%s: Scan(%v) = %v, want %v", tt.name, v, got, codecTestUUID)
+		}
+	}
+}
+
+func testSQLBinaryUUID(t *testing.T) {
+	u := BinaryUUID{UUID: codecTestUUID}
+	v, err := u.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := v.([]byte); !ok || !bytes.Equal(b, codecTestData) {
+		t.Fatalf("Value() = %#v, want %x", v, codecTestData)
+	}
+	var got BinaryUUID
+	if err := got.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if got != u {
+		t.Errorf("Scan(%x) = %v, want %v", v, got, u)
+	}
+}
+
 func testSQLScanBinary(t *testing.T) {
 	got := UUID{}
 	err := got.Scan(codecTestData)
@@ -109,6 +173,77 @@ func testSQLScanNil(t *testing.T) {
 	}
 }
 
+func testSQLScanSources(t *testing.T) {
+	canonical := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	braced := "{" + canonical + "}"
+	base58 := "EJ34kCVxxF9jHMKD4EgrAK"
+	hash := "6ba7b8109dad11d180b400c04fd430c8"
+	var array [Size]byte
+	copy(array[:], codecTestData)
+	var nilString *string
+	var nilUUID *UUID
+
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    UUID
+		wantErr bool
+	}{
+		{name: "nil", src: nil, want: Nil},
+		{name: "UUID", src: codecTestUUID, want: codecTestUUID},
+		{name: "*UUID", src: &codecTestUUID, want: codecTestUUID},
+		{name: "*UUID/nil", src: nilUUID, want: Nil},
+		{name: "[16]byte", src: array, want: codecTestUUID},
+		{name: "[]byte/binary", src: codecTestData, want: codecTestUUID},
+		{name: "[]byte/canonical", src: []byte(canonical), want: codecTestUUID},
+		{name: "[]byte/braced", src: []byte(braced), want: codecTestUUID},
+		{name: "[]byte/hash", src: []byte(hash), want: codecTestUUID},
+		{name: "[]byte/base58", src: []byte(base58), want: codecTestUUID},
+		{name: "[]byte/invalid", src: []byte("bad"), wantErr: true},
+		{name: "RawBytes/binary", src: sql.RawBytes(codecTestData), want: codecTestUUID},
+		{name: "RawBytes/canonical", src: sql.RawBytes(canonical), want: codecTestUUID},
+		{name: "RawBytes/braced", src: sql.RawBytes(braced), want: codecTestUUID},
+		{name: "RawBytes/base58", src: sql.RawBytes(base58), want: codecTestUUID},
+		{name: "string/canonical", src: canonical, want: codecTestUUID},
+		{name: "string/braced", src: braced, want: codecTestUUID},
+		{name: "string/hash", src: hash, want: codecTestUUID},
+		{name: "string/base58", src: base58, want: codecTestUUID},
+		{name: "string/half-braced", src: "{" + canonical + ")", wantErr: true},
+		{name: "*string", src: &canonical, want: codecTestUUID},
+		{name: "*string/braced", src: &braced, want: codecTestUUID},
+		{name: "*string/nil", src: nilString, want: Nil},
+		{name: "int64", src: int64(42), wantErr: true},
+		{name: "time.Time", src: time.Time{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Omni
+			err := got.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Scan(%#v) = %v, want error", tt.src, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%#v): %v", tt.src, err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan(%#v) = %v, want %v", tt.src, got, tt.want)
+			}
+
+			var n NullUUID
+			if err := n.Scan(tt.src); err != nil {
+				t.Fatalf("NullUUID.Scan(%#v): %v", tt.src, err)
+			}
+			wantValid := tt.want != Nil
+			if n.Valid != wantValid || n.UUID != tt.want {
+				t.Errorf("NullUUID.Scan(%#v) = %+v, want {UUID:%v Valid:%t}", tt.src, n, tt.want, wantValid)
+			}
+		})
+	}
+}
+
 func TestNullUUID(t *testing.T) {
 	t.Run("Value", func(t *testing.T) {
 		t.Run("Nil", testNullUUIDValueNil)
@@ -121,6 +256,15 @@ func TestNullUUID(t *testing.T) {
 		t.Run("UUID", testNullUUIDScanUUID)
 	})
 
+	t.Run("Ptr", testNullUUIDPtr)
+
+	t.Run("Text", func(t *testing.T) {
+		t.Run("Valid", testNullUUIDTextValid)
+		t.Run("Null", testNullUUIDTextNull)
+		t.Run("Invalid", testNullUUIDTextInvalid)
+		t.Run("MapKey", testNullUUIDTextMapKey)
+	})
+
 	t.Run("MarshalJSON", func(t *testing.T) {
 		t.Run("Nil", testNullUUIDMarshalJSONNil)
 		t.Run("Null", testNullUUIDMarshalJSONNull)
@@ -132,6 +276,10 @@ func TestNullUUID(t *testing.T) {
 		t.Run("Null", testNullUUIDUnmarshalJSONNull)
 		t.Run("Valid", testNullUUIDUnmarshalJSONValid)
 		t.Run("Malformed", testNullUUIDUnmarshalJSONMalformed)
+		t.Run("Empty", testNullUUIDUnmarshalJSONEmpty)
+		t.Run("Unquoted", testNullUUIDUnmarshalJSONUnquoted)
+		t.Run("Escaped", testNullUUIDUnmarshalJSONEscaped)
+		t.Run("Unchanged", testNullUUIDUnmarshalJSONUnchanged)
 	})
 }
 
@@ -208,6 +356,97 @@ func testNullUUIDScanUUID(t *testing.T) {
 	}
 }
 
+func testNullUUIDPtr(t *testing.T) {
+	if p := (NullUUID{}).Ptr(); p != nil {
+		t.Errorf("invalid NullUUID.Ptr() = %v, want nil", *p)
+	}
+	if u := NullUUIDFromPtr(nil); u.Valid {
+		t.Errorf("NullUUIDFromPtr(nil) = %+v, want invalid", u)
+	}
+
+	u := NullUUID{UUID: codecTestUUID, Valid: true}
+	p := u.Ptr()
+	if p == nil || *p != codecTestUUID {
+		t.Fatalf("%+v.Ptr() = %v, want %v", u, p, codecTestUUID)
+	}
+	p[0] = 0
+	if u.UUID != codecTestUUID {
+		t.Errorf("modifying Ptr() result changed NullUUID to %v", u.UUID)
+	}
+	if got := NullUUIDFromPtr(&codecTestUUID); got != (NullUUID{UUID: codecTestUUID, Valid: true}) {
+		t.Errorf("NullUUIDFromPtr(%v) = %+v, want valid", codecTestUUID, got)
+	}
+}
+
+func testNullUUIDTextValid(t *testing.T) {
+	u := NullUUID{UUID: codecTestUUID, Valid: true}
+	text, err := u.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"; string(text) != want {
+		t.Errorf("%+v.MarshalText() = %s, want %s", u, text, want)
+	}
+	var got NullUUID
+	if err := got.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if got != u {
+		t.Errorf("UnmarshalText(%s) = %+v, want %+v", text, got, u)
+	}
+}
+
+func testNullUUIDTextNull(t *testing.T) {
+	text, err := NullUUID{}.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(text) != 0 {
+		t.Errorf("invalid NullUUID.MarshalText() = %q, want empty", text)
+	}
+	u := NullUUID{UUID: codecTestUUID, Valid: true}
+	if err := u.UnmarshalText(text); err != nil {
+		t.Fatal(err)
+	}
+	if u.Valid || u.UUID != Nil {
+		t.Errorf("UnmarshalText(%q) = %+v, want invalid", text, u)
+	}
+}
+
+func testNullUUIDTextInvalid(t *testing.T) {
+	want := NullUUID{UUID: codecTestUUID, Valid: true}
+	u := want
+	if err := u.UnmarshalText([]byte("null")); err == nil {
+		t.Fatalf("UnmarshalText(null) = %+v, want error", u)
+	}
+	if u != want {
+		t.Errorf("UnmarshalText(null) changed value to %+v, want %+v", u, want)
+	}
+}
+
+func testNullUUIDTextMapKey(t *testing.T) {
+	in := map[NullUUID]int{
+		{UUID: codecTestUUID, Valid: true}: 1,
+		{}:                                 2,
+	}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out map[NullUUID]int
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal(%s): %v", data, err)
+	}
+	if len(out) != len(in) {
+		t.Fatalf("json round trip of %s = %v, want %v", data, out, in)
+	}
+	for k, v := range in {
+		if out[k] != v {
+			t.Errorf("json round trip of %s: out[%+v] = %d, want %d", data, k, out[k], v)
+		}
+	}
+}
+
 func testNullUUIDMarshalJSONNil(t *testing.T) {
 	u := NullUUID{Valid: true}
 
@@ -344,6 +583,105 @@ func testNullUUIDUnmarshalJSONMalformed(t *testing.T) {
 	}
 }
 
+func testNullUUIDUnmarshalJSONEmpty(t *testing.T) {
+	defer func() { EmptyJSONAsNil, EmptyJSONAsNull = false, false }()
+	data := []byte(`""`)
+
+	u := NullUUID{UUID: codecTestUUID, Valid: true}
+	if err := u.UnmarshalJSON(data); err == nil {
+		t.Fatalf("UnmarshalJSON(%s) = %+v, want error", data, u)
+	}
+
+	EmptyJSONAsNil = true
+	if err := u.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON(%s) with EmptyJSONAsNil: %v", data, err)
+	}
+	if want := (NullUUID{UUID: Nil, Valid: true}); u != want {
+		t.Fatalf("UnmarshalJSON(%s) with EmptyJSONAsNil = %+v, want %+v", data, u, want)
+	}
+
+	EmptyJSONAsNull = true
+	if err := u.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON(%s) with EmptyJSONAsNull: %v", data, err)
+	}
+	if want := (NullUUID{}); u != want {
+		t.Fatalf("UnmarshalJSON(%s) with EmptyJSONAsNull = %+v, want %+v", data, u, want)
+	}
+}
+
+func testNullUUIDUnmarshalJSONUnquoted(t *testing.T) {
+	inputs := []string{
+		`6ba7b810-9dad-11d1-80b4-00c04fd430c8`,
+		`"6ba7b810-9dad-11d1-80b4-00c04fd430c8`,
+		`6ba7b810-9dad-11d1-80b4-00c04fd430c8"`,
+		`"`,
+		``,
+		`true`,
+	}
+	for _, data := range inputs {
+		var u NullUUID
+		if err := u.UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) = %+v, want error", data, u)
+		}
+	}
+}
+
+func testNullUUIDUnmarshalJSONEscaped(t *testing.T) {
+	var u NullUUID
+	data := []byte(`"\u0036ba7b810-9dad-11d1-80b4-00c04fd430c\u0038"`)
+	if err := json.Unmarshal(data, &u); err != nil {
+		t.Fatalf("json.Unmarshal(%s): %v", data, err)
+	}
+	if !u.Valid || u.UUID != codecTestUUID {
+		t.Fatalf("json.Unmarshal(%s) = %+v, want %v", data, u, codecTestUUID)
+	}
+}
+
+func testNullUUIDUnmarshalJSONUnchanged(t *testing.T) {
+	want := NullUUID{UUID: codecTestUUID, Valid: true}
+	for _, data := range []string{`"6ba7b810-9dad-11d1-80b4-00c04fd430zz"`, `"\u0020"`, `42`} {
+		u := want
+		if err := u.UnmarshalJSON([]byte(data)); err == nil {
+			t.Fatalf("UnmarshalJSON(%s): want error", data)
+		}
+		if u != want {
+			t.Errorf("UnmarshalJSON(%s) changed value to %+v, want %+v", data, u, want)
+		}
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	b.Run("canonical", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			codecTestUUID.MarshalJSON()
+		}
+	})
+	b.Run("base58", func(b *testing.B) {
+		DefaultFormat = FormatBase58
+		defer func() { DefaultFormat = FormatCanonical }()
+		for i := 0; i < b.N; i++ {
+			codecTestUUID.MarshalJSON()
+		}
+	})
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	data := []byte(`"6ba7b810-9dad-11d1-80b4-00c04fd430c8"`)
+	b.Run("Valid", func(b *testing.B) {
+		var u UUID
+		for i := 0; i < b.N; i++ {
+			u.UnmarshalJSON(data)
+		}
+	})
+	b.Run("Null", func(b *testing.B) {
+		null := []byte("null")
+		var u UUID
+		for i := 0; i < b.N; i++ {
+			u.UnmarshalJSON(null)
+		}
+	})
+}
+
 func BenchmarkNullMarshalJSON(b *testing.B) {
 	b.Run("Valid", func(b *testing.B) {
 		u, err := FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
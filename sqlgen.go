@@ -0,0 +1,54 @@
+package uuid
+
+import "strings"
+
+// sqliteUnixMs is an SQLite expression for the current Unix time in
+// milliseconds. 'now' is constant within a single statement step.
+const sqliteUnixMs = "CAST(ROUND((julianday('now') - 2440587.5) * 86400000) AS INTEGER)"
+
+// PostgresV7Function returns a CREATE FUNCTION statement for a PL/pgSQL
+// function called name that returns a new v7 UUID with the same layout as
+// Gen.NewV7: a 48-bit millisecond Unix timestamp followed by the version,
+// random bits and the RFC-4122 variant. Unlike Gen.NewV7, the bits after the
+// timestamp are always random, so IDs generated within the same millisecond
+// are not ordered. It requires PostgreSQL 13 or later.
+//
+// The name is used as is and must be a valid, optionally schema-qualified,
+// identifier.
+func PostgresV7Function(name string) string {
+	return strings.Replace(postgresV7Function, "{{name}}", name, 1)
+}
+
+// postgresV7Function starts from a random v4 UUID, overlays the timestamp on
+// the first 6 bytes and turns the version from 4 (0100) into 7 (0111).
+const postgresV7Function = `CREATE OR REPLACE FUNCTION {{name}}() RETURNS uuid AS $$
+BEGIN
+	RETURN encode(
+		set_bit(
+			set_bit(
+				overlay(uuid_send(gen_random_uuid())
+					PLACING substring(int8send(floor(extract(epoch FROM clock_timestamp()) * 1000)::bigint) FROM 3)
+					FROM 1 FOR 6),
+				52, 1),
+			53, 1),
+		'hex')::uuid;
+END
+$$ LANGUAGE plpgsql VOLATILE;
+`
+
+// SQLiteV7Expr returns an SQLite expression that evaluates to a new v7 UUID
+// in canonical text form, with the same layout as PostgresV7Function. It can
+// be used as a column default:
+//
+//	CREATE TABLE users (id TEXT PRIMARY KEY DEFAULT (<expr>))
+func SQLiteV7Expr() string {
+	return sqliteV7Expr
+}
+
+const sqliteV7Expr = "lower(" +
+	"substr(printf('%012x', " + sqliteUnixMs + "), 1, 8) || '-' || " +
+	"substr(printf('%012x', " + sqliteUnixMs + "), 9, 4) || '-7' || " +
+	"substr(hex(randomblob(2)), 2, 3) || '-' || " +
+	"substr('89ab', 1 + (random() & 3), 1) || " +
+	"substr(hex(randomblob(2)), 2, 3) || '-' || " +
+	"hex(randomblob(6)))"
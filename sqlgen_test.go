@@ -0,0 +1,38 @@
+package uuid
+
+import "testing"
+
+func TestPostgresV7Function(t *testing.T) {
+	const want = `CREATE OR REPLACE FUNCTION public.uuid_generate_v7() RETURNS uuid AS $$
+BEGIN
+	RETURN encode(
+		set_bit(
+			set_bit(
+				overlay(uuid_send(gen_random_uuid())
+					PLACING substring(int8send(floor(extract(epoch FROM clock_timestamp()) * 1000)::bigint) FROM 3)
+					FROM 1 FOR 6),
+				52, 1),
+			53, 1),
+		'hex')::uuid;
+END
+$$ LANGUAGE plpgsql VOLATILE;
+`
+	if got := PostgresV7Function("public.uuid_generate_v7"); got != want {
+		t.Errorf("PostgresV7Function() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// The SQLite expression is executed and parsed by the tests in dbtest; this
+// only pins its text.
+func TestSQLiteV7Expr(t *testing.T) {
+	const want = "lower(" +
+		"substr(printf('%012x', CAST(ROUND((julianday('now') - 2440587.5) * 86400000) AS INTEGER)), 1, 8) || '-' || " +
+		"substr(printf('%012x', CAST(ROUND((julianday('now') - 2440587.5) * 86400000) AS INTEGER)), 9, 4) || '-7' || " +
+		"substr(hex(randomblob(2)), 2, 3) || '-' || " +
+		"substr('89ab', 1 + (random() & 3), 1) || " +
+		"substr(hex(randomblob(2)), 2, 3) || '-' || " +
+		"hex(randomblob(6)))"
+	if got := SQLiteV7Expr(); got != want {
+		t.Errorf("SQLiteV7Expr() = %q, want %q", got, want)
+	}
+}
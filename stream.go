@@ -0,0 +1,149 @@
+package uuid
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/flexstack/uuid/base58"
+)
+
+var errTruncatedRecord = errors.New("uuid: truncated binary record")
+
+// Scanner reads UUIDs from a stream, either one per line in any format
+// accepted by Parse, or packed as 16-byte binary records. Errors include the
+// line or record number.
+//
+//	s := uuid.NewScanner(r)
+//	for s.Scan() {
+//		u := s.UUID()
+//	}
+//	if err := s.Err(); err != nil {
+//		return err
+//	}
+type Scanner struct {
+	s      *bufio.Scanner
+	binary bool
+	n      int
+	u      UUID
+	err    error
+}
+
+// NewScanner returns a Scanner reading one UUID per line from r. Leading and
+// trailing white space, including "\r", is ignored, and so are empty lines.
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{s: bufio.NewScanner(r)}
+}
+
+// NewBinaryScanner returns a Scanner reading packed 16-byte UUIDs from r,
+// as written by NewBinaryWriter.
+func NewBinaryScanner(r io.Reader) *Scanner {
+	s := bufio.NewScanner(r)
+	s.Split(scanRecords)
+	return &Scanner{s: s, binary: true}
+}
+
+// scanRecords is a bufio.SplitFunc for 16-byte records.
+func scanRecords(data []byte, atEOF bool) (int, []byte, error) {
+	if len(data) >= Size {
+		return Size, data[:Size], nil
+	}
+	if atEOF && len(data) > 0 {
+		return 0, nil, errTruncatedRecord
+	}
+	return 0, nil, nil
+}
+
+// Scan advances to the next UUID, which is then available from UUID. It
+// returns false at the end of the stream or on the first error.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	for s.s.Scan() {
+		s.n++
+		if s.binary {
+			copy(s.u[:], s.s.Bytes())
+			return true
+		}
+		line := bytes.TrimSpace(s.s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := parse(&s.u, line); err != nil {
+			s.err = fmt.Errorf("uuid: line %d: %w", s.n, err)
+			return false
+		}
+		return true
+	}
+	if err := s.s.Err(); err != nil {
+		s.err = fmt.Errorf("uuid: %s %d: %w", s.unit(), s.n+1, err)
+	}
+	return false
+}
+
+func (s *Scanner) unit() string {
+	if s.binary {
+		return "record"
+	}
+	return "line"
+}
+
+// UUID returns the UUID read by the last call to Scan.
+func (s *Scanner) UUID() UUID {
+	return s.u
+}
+
+// Err returns the first error, or nil at the end of the stream.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// Writer writes UUIDs to a buffered stream, either one per line in a
+// Format, or packed as 16-byte binary records. Call Flush when done.
+type Writer struct {
+	w      *bufio.Writer
+	format Format
+	binary bool
+}
+
+// NewWriter returns a Writer writing one UUID per line to w, in format.
+func NewWriter(w io.Writer, format Format) *Writer {
+	return &Writer{w: bufio.NewWriter(w), format: format}
+}
+
+// NewBinaryWriter returns a Writer writing packed 16-byte UUIDs to w.
+func NewBinaryWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w), binary: true}
+}
+
+// Write writes u to the buffer.
+func (w *Writer) Write(u UUID) error {
+	if w.binary {
+		_, err := w.w.Write(u[:])
+		return err
+	}
+	var buf [37]byte
+	var n int
+	switch w.format {
+	case FormatCanonical:
+		encodeCanonical(buf[:], u)
+		n = 36
+	case FormatHash:
+		encodeHash(buf[:], u)
+		n = 32
+	default:
+		base58.MarshalBytes(buf[:], u[:])
+		n = 22
+	}
+	buf[n] = '\n'
+	_, err := w.w.Write(buf[:n+1])
+	return err
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
@@ -0,0 +1,199 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestScanner(t *testing.T) {
+	t.Run("Formats", testScannerFormats)
+	t.Run("LineErrors", testScannerLineErrors)
+	t.Run("LongLine", testScannerLongLine)
+	t.Run("Empty", testScannerEmpty)
+}
+
+func scanAll(s *Scanner) []UUID {
+	var ids []UUID
+	for s.Scan() {
+		ids = append(ids, s.UUID())
+	}
+	return ids
+}
+
+func testScannerFormats(t *testing.T) {
+	in := "6ba7b810-9dad-11d1-80b4-00c04fd430c8\r\n" +
+		"\n" +
+		"  6BA7B8109DAD11D180B400C04FD430C8  \n" +
+		"EJ34kCVxxF9jHMKD4EgrAK\n" +
+		"00000000-0000-0000-0000-000000000000"
+	s := NewScanner(strings.NewReader(in))
+	ids := scanAll(s)
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []UUID{codecTestUUID, codecTestUUID, codecTestUUID, Nil}
+	if len(ids) != len(want) {
+		t.Fatalf("scanned %d UUIDs, want %d", len(ids), len(want))
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("UUID %d = %v, want %v", i, ids[i], want[i])
+		}
+	}
+}
+
+func testScannerLineErrors(t *testing.T) {
+	in := codecTestUUID.String() + "\n\n" + "6ba7b810-9dad-11d1-80b4-00c04fd430cx\n" + codecTestUUID.String() + "\n"
+	s := NewScanner(strings.NewReader(in))
+	if ids := scanAll(s); len(ids) != 1 {
+		t.Errorf("scanned %d UUIDs before the error, want 1", len(ids))
+	}
+	if err := s.Err(); err == nil || !strings.Contains(err.Error(), "line 3:") {
+		t.Errorf("Err() = %v, want an error on line 3", err)
+	}
+	if s.Scan() {
+		t.Error("Scan() after an error = true")
+	}
+}
+
+func testScannerLongLine(t *testing.T) {
+	in := codecTestUUID.String() + "\n" + strings.Repeat("x", 1<<17) + "\n"
+	s := NewScanner(strings.NewReader(in))
+	scanAll(s)
+	if err := s.Err(); err == nil || !strings.Contains(err.Error(), "line 2:") {
+		t.Errorf("Err() = %v, want an error on line 2", err)
+	}
+}
+
+func testScannerEmpty(t *testing.T) {
+	for _, s := range []*Scanner{NewScanner(strings.NewReader("")), NewBinaryScanner(bytes.NewReader(nil))} {
+		if s.Scan() || s.Err() != nil {
+			t.Errorf("Scan() of an empty stream = true or Err() = %v", s.Err())
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	ids := []UUID{codecTestUUID, Nil, Omni}
+	for _, format := range formats {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, format)
+		for _, u := range ids {
+			if err := w.Write(u); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if buf.Len() != 0 {
+			t.Errorf("%s: Writer wrote %d bytes before Flush", format, buf.Len())
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		var want string
+		for _, u := range ids {
+			want += u.Format(format) + "\n"
+		}
+		if buf.String() != want {
+			t.Errorf("%s: wrote %q, want %q", format, buf.String(), want)
+		}
+
+		s := NewScanner(&buf)
+		got := scanAll(s)
+		if s.Err() != nil || len(got) != len(ids) || got[0] != ids[0] || got[2] != ids[2] {
+			t.Errorf("%s: scanned %v, %v, want %v", format, got, s.Err(), ids)
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestWriterError(t *testing.T) {
+	w := NewWriter(failingWriter{}, FormatCanonical)
+	w.Write(codecTestUUID)
+	if err := w.Flush(); err == nil {
+		t.Error("Flush() to a failing writer did not fail")
+	}
+}
+
+func TestBinaryStream(t *testing.T) {
+	ids := make([]UUID, 1000)
+	for i := range ids {
+		ids[i] = randomTestUUID()
+	}
+	var buf bytes.Buffer
+	w := NewBinaryWriter(&buf)
+	for _, u := range ids {
+		if err := w.Write(u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != len(ids)*Size {
+		t.Fatalf("wrote %d bytes, want %d", buf.Len(), len(ids)*Size)
+	}
+	if !bytes.Equal(buf.Bytes()[:Size], ids[0][:]) {
+		t.Errorf("first record = %x, want %x", buf.Bytes()[:Size], ids[0][:])
+	}
+
+	s := NewBinaryScanner(bytes.NewReader(buf.Bytes()))
+	got := scanAll(s)
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(ids) {
+		t.Fatalf("scanned %d UUIDs, want %d", len(got), len(ids))
+	}
+	for i := range ids {
+		if got[i] != ids[i] {
+			t.Fatalf("UUID %d = %v, want %v", i, got[i], ids[i])
+		}
+	}
+
+	s = NewBinaryScanner(bytes.NewReader(buf.Bytes()[:2*Size+5]))
+	if got := scanAll(s); len(got) != 2 {
+		t.Errorf("scanned %d UUIDs from a truncated stream, want 2", len(got))
+	}
+	if err := s.Err(); !errors.Is(err, errTruncatedRecord) || !strings.Contains(err.Error(), "record 3:") {
+		t.Errorf("Err() = %v, want %v in record 3", err, errTruncatedRecord)
+	}
+}
+
+func BenchmarkScanner(b *testing.B) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, FormatCanonical)
+	for i := 0; i < 1024; i++ {
+		w.Write(randomTestUUID())
+	}
+	w.Flush()
+	data := buf.Bytes()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		s := NewScanner(bytes.NewReader(data))
+		for s.Scan() {
+		}
+		if s.Err() != nil {
+			b.Fatal(s.Err())
+		}
+	}
+}
+
+func BenchmarkWriter(b *testing.B) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, FormatCanonical)
+	u := randomTestUUID()
+	for i := 0; i < b.N; i++ {
+		if i%1024 == 0 {
+			w.Flush()
+			buf.Reset()
+		}
+		w.Write(u)
+	}
+}
@@ -84,7 +84,7 @@ const (
 // releases until the spec is final.
 func TimestampFromV7(u UUID) (int64, error) {
 	if u.Version() != 7 {
-		return 0, fmt.Errorf("uuid: %s is version %d, not version 6", u, u.Version())
+		return 0, fmt.Errorf("uuid: %s is version %d, not version 7", u, u.Version())
 	}
 
 	t := 0 |
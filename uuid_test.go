@@ -213,6 +213,12 @@ func TestTimestampFromV7(t *testing.T) {
 			t.Errorf("TimestampFromV7(%v) got %v, want %v", tt.u, got, tt.want)
 		}
 	}
+
+	u := Must(FromString("6ba7b810-9dad-41d1-80b4-00c04fd430c8"))
+	want := "uuid: 6ba7b810-9dad-41d1-80b4-00c04fd430c8 is version 4, not version 7"
+	if _, err := TimestampFromV7(u); err == nil || err.Error() != want {
+		t.Errorf("TimestampFromV7(%v) error = %v, want %s", u, err, want)
+	}
 }
 
 func BenchmarkNewV4(b *testing.B) {
@@ -383,6 +389,49 @@ func TestV7RapidGeneration(t *testing.T) {
 	}
 }
 
+func TestV7CounterOverflow(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	g := NewGenWithOptions(
+		WithEpochFunc(func() time.Time { return now }),
+		WithRandomReader(zeroReader{}),
+	)
+	// The counter starts at zero, so the 4097th UUID in one millisecond
+	// wraps its 12 bits.
+	prev := Must(g.NewV7())
+	for i := 1; i <= 4096; i++ {
+		u := Must(g.NewV7())
+		if Compare(prev, u) >= 0 {
+			t.Fatalf("NewV7() #%d = %v, want after %v", i, u, prev)
+		}
+		prev = u
+	}
+	ts, err := TimestampFromV7(prev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := now.UnixMilli() + 1; ts != want {
+		t.Errorf("timestamp after counter overflow = %d, want %d", ts, want)
+	}
+}
+
+func TestV7ClockBackwards(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	g := NewGenWithOptions(WithEpochFunc(func() time.Time { return now }))
+	u1 := Must(g.NewV7())
+	now = now.Add(-time.Second)
+	u2 := Must(g.NewV7())
+	if Compare(u1, u2) >= 0 {
+		t.Errorf("NewV7() after the clock went backwards = %v, want after %v", u2, u1)
+	}
+	ts, err := TimestampFromV7(u2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(time.Second).UnixMilli(); ts != want {
+		t.Errorf("timestamp after the clock went backwards = %d, want %d", ts, want)
+	}
+}
+
 func TestV4Correctness(t *testing.T) {
 	for i := 0; i < 1000; i++ {
 		u, err := NewV4()
@@ -0,0 +1,155 @@
+// Package uuidtest provides deterministic UUID generators, a controllable
+// clock and assertion helpers for tests.
+package uuidtest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flexstack/uuid"
+)
+
+// Epoch is the time a Clock created by NewSeeded starts at.
+var Epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// NewSeeded returns a generator whose V4 and V7 UUIDs are determined by
+// seed. Its clock is frozen at Epoch, so V7 UUIDs only differ by their
+// counter and random bits. Pass uuid.WithEpochFunc with a Clock's Now to
+// control the time.
+func NewSeeded(seed int64, opts ...uuid.GenOption) *uuid.Gen {
+	opts = append([]uuid.GenOption{
+		uuid.WithRandomReader(&lockedReader{r: rand.New(rand.NewSource(seed))}),
+		uuid.WithEpochFunc(NewClock(Epoch).Now),
+	}, opts...)
+	return uuid.NewGenWithOptions(opts...)
+}
+
+// lockedReader makes a math/rand source safe for concurrent use.
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (l *lockedReader) Read(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.r.Read(p)
+}
+
+// Sequential is a generator returning 00000000-0000-0000-0000-000000000001,
+// 00000000-0000-0000-0000-000000000002, and so on. The version and variant
+// bits are not set, so the UUIDs stay readable in golden files.
+type Sequential struct {
+	mu sync.Mutex
+	n  uint64
+}
+
+var _ uuid.Generator = (*Sequential)(nil)
+
+// NewSequential returns a Sequential generator starting at 1.
+func NewSequential() *Sequential {
+	return &Sequential{}
+}
+
+// Next returns the next UUID.
+func (s *Sequential) Next() uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n++
+	var u uuid.UUID
+	binary.BigEndian.PutUint64(u[8:], s.n)
+	return u
+}
+
+// NewV4 returns the next UUID.
+func (s *Sequential) NewV4() (uuid.UUID, error) {
+	return s.Next(), nil
+}
+
+// NewV7 returns the next UUID.
+func (s *Sequential) NewV7() (uuid.UUID, error) {
+	return s.Next(), nil
+}
+
+// Reset restarts the sequence at 1.
+func (s *Sequential) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n = 0
+}
+
+// Clock is a clock for uuid.WithEpochFunc that is frozen until it is set or
+// advanced, or that steps forward on every call to Now.
+type Clock struct {
+	mu   sync.Mutex
+	t    time.Time
+	step time.Duration
+}
+
+// NewClock returns a Clock frozen at t.
+func NewClock(t time.Time) *Clock {
+	return &Clock{t: t}
+}
+
+// Now returns the time of the clock, then advances it by the step.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.t
+	c.t = c.t.Add(c.step)
+	return t
+}
+
+// Set sets the time of the clock.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = t
+}
+
+// Advance moves the clock forward by d.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+// SetStep sets how far the clock advances on every call to Now. A step of
+// zero freezes the clock.
+func (c *Clock) SetStep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.step = d
+}
+
+// MustParse returns the UUID parsed from s in any supported format. It
+// panics if s is not a UUID, so it can initialize test variables.
+func MustParse(s string) uuid.UUID {
+	u, err := uuid.FromString(s)
+	if err != nil {
+		panic(fmt.Sprintf("uuidtest: MustParse(%q): %v", s, err))
+	}
+	return u
+}
+
+// Equal reports whether got and want are equal. If they are not, it marks
+// the test as failed with both UUIDs, their versions and the first byte
+// that differs.
+func Equal(tb testing.TB, got, want uuid.UUID) bool {
+	tb.Helper()
+	if got == want {
+		return true
+	}
+	i := 0
+	for got[i] == want[i] {
+		i++
+	}
+	tb.Errorf("UUIDs differ at byte %d:\n\tgot:  %s (version %d)\n\twant: %s (version %d)",
+		i, got.Format(uuid.FormatCanonical), got.Version(), want.Format(uuid.FormatCanonical), want.Version())
+	return false
+}
@@ -0,0 +1,159 @@
+package uuidtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flexstack/uuid"
+)
+
+func TestNewSeeded(t *testing.T) {
+	t.Run("Deterministic", testNewSeededDeterministic)
+	t.Run("Golden", testNewSeededGolden)
+	t.Run("Clock", testNewSeededClock)
+}
+
+func generate(g uuid.Generator, n int) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, 2*n)
+	for i := 0; i < n; i++ {
+		ids = append(ids, uuid.Must(g.NewV4()), uuid.Must(g.NewV7()))
+	}
+	return ids
+}
+
+func testNewSeededDeterministic(t *testing.T) {
+	a, b := generate(NewSeeded(1), 100), generate(NewSeeded(1), 100)
+	for i := range a {
+		Equal(t, a[i], b[i])
+	}
+	c := generate(NewSeeded(2), 100)
+	if a[0] == c[0] {
+		t.Errorf("seeds 1 and 2 both start with %v", a[0])
+	}
+	for i, u := range a {
+		want := byte(uuid.V4)
+		if i%2 == 1 {
+			want = uuid.V7
+		}
+		if u.Version() != want || u.Variant() != uuid.VariantRFC4122 {
+			t.Fatalf("UUID %d = %v, want version %d", i, u, want)
+		}
+	}
+}
+
+func testNewSeededGolden(t *testing.T) {
+	g := NewSeeded(42)
+	got := []string{
+		uuid.Must(g.NewV4()).String(),
+		uuid.Must(g.NewV7()).String(),
+		uuid.Must(g.NewV7()).String(),
+	}
+	want := []string{
+		"538c7f96-b164-4f1b-97bb-9f4bb472e89f",
+		"018cc251-f400-7b14-84f2-5209c9d9343e",
+		"018cc251-f400-7b15-92ba-09dd9d52dfd7",
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("UUID %d = %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	// The example in the README.
+	clock := NewClock(Epoch)
+	g = NewSeeded(42, uuid.WithEpochFunc(clock.Now))
+	clock.Advance(time.Hour)
+	Equal(t, uuid.Must(g.NewV7()), MustParse("018cc288-e280-738c-bf96-b164bf1b97bb"))
+}
+
+func testNewSeededClock(t *testing.T) {
+	ts, err := uuid.TimestampFromV7(uuid.Must(NewSeeded(1).NewV7()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts != Epoch.UnixMilli() {
+		t.Errorf("V7 timestamp = %d, want Epoch %d", ts, Epoch.UnixMilli())
+	}
+
+	clock := NewClock(Epoch)
+	g := NewSeeded(1, uuid.WithEpochFunc(clock.Now))
+	clock.Advance(time.Hour)
+	ts, _ = uuid.TimestampFromV7(uuid.Must(g.NewV7()))
+	if want := Epoch.Add(time.Hour).UnixMilli(); ts != want {
+		t.Errorf("V7 timestamp = %d, want %d", ts, want)
+	}
+}
+
+func TestSequential(t *testing.T) {
+	s := NewSequential()
+	Equal(t, uuid.Must(s.NewV4()), MustParse("00000000-0000-0000-0000-000000000001"))
+	Equal(t, uuid.Must(s.NewV7()), MustParse("00000000-0000-0000-0000-000000000002"))
+	for i := 0; i < 253; i++ {
+		s.Next()
+	}
+	Equal(t, s.Next(), MustParse("00000000-0000-0000-0000-000000000100"))
+	s.Reset()
+	Equal(t, s.Next(), MustParse("00000000-0000-0000-0000-000000000001"))
+}
+
+func TestClock(t *testing.T) {
+	c := NewClock(Epoch)
+	if c.Now() != Epoch || c.Now() != Epoch {
+		t.Fatal("NewClock is not frozen")
+	}
+	c.Advance(time.Second)
+	if got := c.Now(); got != Epoch.Add(time.Second) {
+		t.Errorf("Now() after Advance = %v", got)
+	}
+	c.Set(Epoch)
+	c.SetStep(time.Millisecond)
+	for i := 0; i < 3; i++ {
+		if got, want := c.Now(), Epoch.Add(time.Duration(i)*time.Millisecond); got != want {
+			t.Errorf("Now() = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if got := MustParse("YcVfxkQb6JRzqk5kF2tNLv"); got != uuid.Omni {
+		t.Errorf("MustParse(base58 Omni) = %v", got)
+	}
+	defer func() {
+		r := recover()
+		if r == nil || !strings.Contains(fmt.Sprint(r), `"bad"`) {
+			t.Errorf("MustParse(bad) panicked with %v", r)
+		}
+	}()
+	MustParse("bad")
+}
+
+// recorder records the failures of a test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestEqual(t *testing.T) {
+	r := &recorder{}
+	if !Equal(r, uuid.Nil, uuid.Nil) || len(r.errors) != 0 {
+		t.Fatalf("Equal(Nil, Nil) failed: %v", r.errors)
+	}
+	got := MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	want := MustParse("6ba7b810-9dad-41d1-80b4-00c04fd430c8")
+	if Equal(r, got, want) || len(r.errors) != 1 {
+		t.Fatalf("Equal of different UUIDs: errors %v", r.errors)
+	}
+	for _, s := range []string{"byte 6", got.String(), want.String(), "version 1", "version 4"} {
+		if !strings.Contains(r.errors[0], s) {
+			t.Errorf("failure %q doesn't mention %q", r.errors[0], s)
+		}
+	}
+}